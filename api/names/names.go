@@ -27,6 +27,18 @@ var ManifestDir = "./bindata"
 // NMStateResourceName is the name of the CR that the operator will reconcile
 const NMStateResourceName = "nmstate"
 
+// HandlerPoolLabelKey labels the nodes served by the handler DaemonSet of an
+// NMState handler pool
+const HandlerPoolLabelKey = "nmstate.io/handler-pool"
+
+// NodeLabelKey labels the objects kept per node with the node name, it is
+// the key of the enactments and NodeNetworkInterfaces node labels too
+const NodeLabelKey = "nmstate.io/node"
+
+// NMStateUninstallFinalizer holds the NMState deletion until the operator
+// removes what it deployed
+const NMStateUninstallFinalizer = "nmstate.io/uninstall"
+
 // Relationship labels
 const ComponentLabelKey = "app.kubernetes.io/component"
 const PartOfLabelKey = "app.kubernetes.io/part-of"
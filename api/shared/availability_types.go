@@ -0,0 +1,72 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Availability configures the PodDisruptionBudgets and the topology spread
+// of the Deployments deployed by the operator, the operator defaults are used
+// for the Deployments that are not specified.
+type Availability struct {
+	// Webhook configures the nmstate-webhook Deployment
+	// +optional
+	Webhook *DeploymentAvailability `json:"webhook,omitempty"`
+	// Metrics configures the nmstate-metrics Deployment
+	// +optional
+	Metrics *DeploymentAvailability `json:"metrics,omitempty"`
+	// ConsolePlugin configures the console plugin Deployment, it is only
+	// deployed at OpenShift.
+	// +optional
+	ConsolePlugin *DeploymentAvailability `json:"consolePlugin,omitempty"`
+}
+
+// DeploymentAvailability replaces the operator defaults of a Deployment
+// PodDisruptionBudget and pods topology spread.
+type DeploymentAvailability struct {
+	// PodDisruptionBudget replaces the default budget, that keeps all the
+	// replicas but one available. The replicas follow the number of infra
+	// nodes, so the default budget never blocks the drain of a single infra
+	// node cluster.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetConfiguration `json:"podDisruptionBudget,omitempty"`
+	// TopologySpreadConstraints replaces the default constraints, that spread
+	// the pods across the infra nodes. The labelSelector of a constraint
+	// defaults to the Deployment pods.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// PodDisruptionBudgetConfiguration sets the budget of the pods that can be
+// evicted, only one of MinAvailable and MaxUnavailable can be set.
+// +kubebuilder:validation:XValidation:rule="!(has(self.minAvailable) && has(self.maxUnavailable))",message="only one of minAvailable and maxUnavailable can be set"
+//
+//nolint:lll
+type PodDisruptionBudgetConfiguration struct {
+	// MinAvailable is the number or percentage of pods that have to stay
+	// available during an eviction.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// MaxUnavailable is the number or percentage of pods that can be
+	// unavailable during an eviction.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
@@ -0,0 +1,47 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+// ComponentStatus is the readiness of a Deployment or DaemonSet deployed by
+// the operator.
+type ComponentStatus struct {
+	// Name of the component: handler, webhook, metrics, cert-manager or
+	// console-plugin
+	Name string `json:"name"`
+	// Kind of the component workload, Deployment or DaemonSet
+	Kind string `json:"kind"`
+	// Workload is the name of the component Deployment or DaemonSet
+	Workload string `json:"workload"`
+	// Desired is the number of replicas, or nodes for a DaemonSet, that should
+	// run the component
+	Desired int32 `json:"desired"`
+	// Ready is the number of ready replicas, or nodes for a DaemonSet
+	Ready int32 `json:"ready"`
+	// Image of the component container
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Version of the component, from the app.kubernetes.io/version label or
+	// the image tag
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// VersionCount is the number of nodes running a version
+type VersionCount struct {
+	Version string `json:"version"`
+	Nodes   int32  `json:"nodes"`
+}
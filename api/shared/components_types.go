@@ -0,0 +1,60 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import corev1 "k8s.io/api/core/v1"
+
+// Components overrides the pods of the components deployed by the operator,
+// the operator defaults are used for the components that are not specified.
+type Components struct {
+	// Handler overrides the nmstate-handler DaemonSet pods
+	// +optional
+	Handler *ComponentOverrides `json:"handler,omitempty"`
+	// Webhook overrides the nmstate-webhook Deployment pods
+	// +optional
+	Webhook *ComponentOverrides `json:"webhook,omitempty"`
+	// Metrics overrides the nmstate-metrics Deployment pods
+	// +optional
+	Metrics *ComponentOverrides `json:"metrics,omitempty"`
+}
+
+// ComponentOverrides replaces the operator defaults of a component pod and its
+// container.
+type ComponentOverrides struct {
+	// Resources replaces the default compute resources of the container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// PriorityClassName replaces the default priority class of the pod.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// Image replaces the image configured at the operator with
+	// RELATED_IMAGE_HANDLER_IMAGE.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// ImagePullPolicy replaces the pull policy configured at the operator with
+	// HANDLER_IMAGE_PULL_POLICY.
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// ImagePullSecrets are added to the pod to pull the image.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Env is added to the container environment, a variable named like one
+	// set by the operator replaces it.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
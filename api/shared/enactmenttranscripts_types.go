@@ -0,0 +1,40 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// EnactmentTranscripts configures the capture of the apply, probes and rollback
+// transcript of every enactment attempt, it is stored at the
+// "<enactment>-transcript" ConfigMap of the handler namespace.
+type EnactmentTranscripts struct {
+	// Size is the maximum number of attempts kept per enactment, the oldest
+	// ones are dropped first.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=20
+	// +optional
+	Size int32 `json:"size,omitempty"`
+	// MaxBytes caps every attempt transcript, the beginning of longer ones
+	// is dropped. It defaults to 65536.
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=262144
+	// +optional
+	MaxBytes int32 `json:"maxBytes,omitempty"`
+	// TTL is how long an attempt transcript is kept, older ones are dropped.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
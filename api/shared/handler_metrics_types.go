@@ -0,0 +1,33 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+// HandlerMetrics enables the handler apply and probe metrics. The handler
+// runs at the host network, so they are served at every node port and
+// scraped through their own Service.
+type HandlerMetrics struct {
+	// Address is the node IP the handler metrics bind to, all the node
+	// addresses if it is empty.
+	// +kubebuilder:validation:XValidation:rule="isIP(self)",message="address must be an IP"
+	// +optional
+	Address string `json:"address,omitempty"`
+	// Port is the node port serving the handler metrics, it has to be free at
+	// every node running the handler.
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
@@ -0,0 +1,49 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+// HandlerRolloutState is the state of the rollout of a handler DaemonSet
+type HandlerRolloutState string
+
+const (
+	// HandlerRolloutStateRolledOut means every handler pod runs the DaemonSet
+	// pod template
+	HandlerRolloutStateRolledOut HandlerRolloutState = "RolledOut"
+	// HandlerRolloutStateRollingOut means the operator is restarting the
+	// outdated handler pods
+	HandlerRolloutStateRollingOut HandlerRolloutState = "RollingOut"
+	// HandlerRolloutStateHeld means an updated handler pod does not get ready
+	// and the outdated ones are kept running
+	HandlerRolloutStateHeld HandlerRolloutState = "Held"
+)
+
+// HandlerRolloutStatus is the progress of the rollout of a handler DaemonSet,
+// the operator restarts its outdated pods on the nodes without Progressing
+// enactments.
+type HandlerRolloutStatus struct {
+	// DaemonSet is the name of the handler DaemonSet
+	DaemonSet string `json:"daemonSet"`
+	// State of the rollout
+	State HandlerRolloutState `json:"state"`
+	// Desired is the number of nodes that should run the handler
+	Desired int32 `json:"desired"`
+	// Updated is the number of handler pods running the DaemonSet pod template
+	Updated int32 `json:"updated"`
+	// Message describes what the rollout is waiting for
+	// +optional
+	Message string `json:"message,omitempty"`
+}
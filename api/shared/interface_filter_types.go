@@ -0,0 +1,47 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+// InterfaceFilter selects the interfaces reported at NodeNetworkState,
+// interfaces not matching it are not reported, neither are the routes
+// using them.
+type InterfaceFilter struct {
+	// IncludeNames is a list of glob patterns, if specified only the interfaces
+	// with a name matching one of them are reported.
+	// +optional
+	IncludeNames []string `json:"includeNames,omitempty"`
+	// ExcludeNames is a list of glob patterns, interfaces with a name matching
+	// one of them are not reported, for example "veth*".
+	// +optional
+	ExcludeNames []string `json:"excludeNames,omitempty"`
+	// IncludeTypes is a list of nmstate interface types, if specified only the
+	// interfaces of one of those types are reported.
+	// +optional
+	IncludeTypes []string `json:"includeTypes,omitempty"`
+	// ExcludeTypes is a list of nmstate interface types that are not reported,
+	// for example "veth" or "tun".
+	// +optional
+	ExcludeTypes []string `json:"excludeTypes,omitempty"`
+	// ExcludeStates is a list of nmstate interface states that are not reported,
+	// for example "ignore" or "down".
+	// +optional
+	ExcludeStates []string `json:"excludeStates,omitempty"`
+	// DropDynamicRoutes removes the running routes that are not part of the
+	// routes configuration, like the ones learned from DHCP or routing daemons.
+	// +optional
+	DropDynamicRoutes bool `json:"dropDynamicRoutes,omitempty"`
+}
@@ -0,0 +1,35 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+// DefaultMaxInterfacesPerNode is the per interface metrics cardinality limit
+// used when it is not configured
+const DefaultMaxInterfacesPerNode = 64
+
+// InterfaceMetrics configures the per interface link health metrics: oper
+// state, MTU, speed, bond members and LLDP neighbors.
+type InterfaceMetrics struct {
+	// IncludeNames is a list of glob patterns, if specified only the interfaces
+	// with a name matching one of them are reported, for example "eth*".
+	// +optional
+	IncludeNames []string `json:"includeNames,omitempty"`
+	// MaxInterfacesPerNode limits the interfaces reported per node to bound the
+	// metrics cardinality, the interfaces are taken sorted by name. Defaults to 64.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxInterfacesPerNode int32 `json:"maxInterfacesPerNode,omitempty"`
+}
@@ -0,0 +1,44 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// Monitoring configures the PrometheusRule rendered by the operator when the
+// Prometheus operator CRDs are installed.
+type Monitoring struct {
+	// DisableAlerts keeps only the recording rules at the PrometheusRule.
+	// +optional
+	DisableAlerts bool `json:"disableAlerts,omitempty"`
+	// PolicyDegradedFor is how long a NodeNetworkConfigurationPolicy has to be
+	// degraded to fire an alert. Defaults to 10m.
+	// +optional
+	PolicyDegradedFor *metav1.Duration `json:"policyDegradedFor,omitempty"`
+	// EnactmentFailingFor is how long a NodeNetworkConfigurationEnactment has
+	// to be failing to fire an alert. Defaults to 10m.
+	// +optional
+	EnactmentFailingFor *metav1.Duration `json:"enactmentFailingFor,omitempty"`
+	// HandlerNotReadyFor is how long a handler pod has to be not ready to fire
+	// an alert, it needs the kube-state-metrics daemonset metrics. Defaults to 10m.
+	// +optional
+	HandlerNotReadyFor *metav1.Duration `json:"handlerNotReadyFor,omitempty"`
+	// NodeNetworkStateStaleAfter is how long the handler can go without
+	// refreshing the NodeNetworkState of its node before firing an alert, it
+	// needs the handler metrics. Defaults to 10m.
+	// +optional
+	NodeNetworkStateStaleAfter *metav1.Duration `json:"nodeNetworkStateStaleAfter,omitempty"`
+}
@@ -21,9 +21,15 @@ const (
 	NmstateConditionAvailable   ConditionType = "Available"
 	NmstateConditionDegraded    ConditionType = "Degraded"
 	NmstateConditionProgressing ConditionType = "Progressing"
+	// NmstateConditionManifestOverlaysApplied reports if the manifest
+	// overlays were applied
+	NmstateConditionManifestOverlaysApplied ConditionType = "ManifestOverlaysApplied"
 
 	NmstateInternalError             ConditionReason = "InternalError"
 	NmstateDeploying                 ConditionReason = "Deploying"
 	NmstateSuccessfullyDeployed      ConditionReason = "SuccessfullyDeployed"
 	NmstateTLSProfileNotFullyHonored ConditionReason = "TLSProfileNotFullyHonored"
+	NmstateManifestOverlaysApplied   ConditionReason = "ManifestOverlaysApplied"
+	NmstateInvalidManifestOverlay    ConditionReason = "InvalidManifestOverlay"
+	NmstateManifestOverlayUnmatched  ConditionReason = "ManifestOverlayUnmatched"
 )
@@ -48,6 +48,11 @@ type NodeNetworkConfigurationPolicySpec struct {
 	// of machines that can be updating at a time. Default is "50%".
 	// +optional
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// RetryPolicy overrides the NMState reconcileConfiguration retries and
+	// backoff for this policy.
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
 }
 
 // NodeNetworkConfigurationPolicyStatus defines the observed state of NodeNetworkConfigurationPolicy
@@ -56,15 +61,62 @@ type NodeNetworkConfigurationPolicyStatus struct {
 
 	// UnavailableNodeCount represents the total number of potentially unavailable nodes that are
 	// processing a NodeNetworkConfigurationPolicy
+	// Deprecated: the maxUnavailable slots are Leases at the handler namespace,
+	// it is cleared when the policy status is updated.
 	// +optional
 	UnavailableNodeCount int `json:"unavailableNodeCount,omitempty" optional:"true"`
 	// UnavailableNodeCountMap represents the total number of potentially unavailable nodes that are
 	// processing a NodeNetworkConfigurationPolicy per Generation (Map Key)
+	// Deprecated: the maxUnavailable slots are Leases at the handler namespace,
+	// it is cleared when the policy status is updated.
 	// +optional
 	UnavailableNodeCountMap map[string]int `json:"unavailableNodeCountMap,omitempty" optional:"true"`
 	// LastUnavailableNodeCountUpdate is time of the last UnavailableNodeCount update
+	// Deprecated: the maxUnavailable slots are Leases at the handler namespace,
+	// it is cleared when the policy status is updated.
 	// +optional
 	LastUnavailableNodeCountUpdate *metav1.Time `json:"lastUnavailableNodeCountUpdate,omitempty" optional:"true"`
+	// Nodes summarizes the enactments of the policy at the matching nodes
+	// +optional
+	Nodes *NodeNetworkConfigurationPolicyNodesStatus `json:"nodes,omitempty" optional:"true"`
+}
+
+// NodeNetworkConfigurationPolicyNodesStatus counts the enactments of the
+// current policy generation per state and lists the nodes with issues
+type NodeNetworkConfigurationPolicyNodesStatus struct {
+	// Matching is the number of nodes running the handler matching the policy node selector
+	Matching int `json:"matching"`
+	// +optional
+	Available int `json:"available,omitempty"`
+	// +optional
+	Failing int `json:"failing,omitempty"`
+	// +optional
+	Aborted int `json:"aborted,omitempty"`
+	// +optional
+	Pending int `json:"pending,omitempty"`
+	// +optional
+	Progressing int `json:"progressing,omitempty"`
+	// Summary is the progress in a human readable way, for example "12/20 Available, 1 Failing"
+	// +optional
+	Summary string `json:"summary,omitempty"`
+	// Issues lists the failing, aborted and pending nodes, it is bounded so
+	// the ones not listed are counted at OmittedIssues
+	// +optional
+	Issues []NodeNetworkConfigurationPolicyNodeIssue `json:"issues,omitempty"`
+	// OmittedIssues is the number of nodes with issues not listed
+	// +optional
+	OmittedIssues int `json:"omittedIssues,omitempty"`
+}
+
+// NodeNetworkConfigurationPolicyNodeIssue is a node where the policy enactment is
+// failing, aborted or pending
+type NodeNetworkConfigurationPolicyNodeIssue struct {
+	Node string `json:"node"`
+	// State is the enactment condition that is true: Failing, Aborted or Pending
+	State ConditionType `json:"state"`
+	// Reason is the shortened message of the enactment condition
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 const (
@@ -87,3 +139,9 @@ const (
 	NodeNetworkConfigurationPolicyConditionConfigurationProgressing    ConditionReason = "ConfigurationProgressing"
 	NodeNetworkConfigurationPolicyConditionConfigurationNoMatchingNode ConditionReason = "NoMatchingNode"
 )
+
+const (
+	// NodeNetworkConfigurationPolicyVerboseAnnotation set to "true" runs
+	// nmstatectl with verbose logging when applying the policy
+	NodeNetworkConfigurationPolicyVerboseAnnotation = "nmstate.io/verbose"
+)
@@ -0,0 +1,54 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeNetworkStateLayout defines how the node network state is reported
+type NodeNetworkStateLayout string
+
+const (
+	// NodeNetworkStateLayoutAggregate reports the whole state at the NodeNetworkState (default)
+	NodeNetworkStateLayoutAggregate NodeNetworkStateLayout = "Aggregate"
+	// NodeNetworkStateLayoutPerInterface reports every interface at its own NodeNetworkInterface,
+	// the NodeNetworkState only keeps the interfaces name, type and state
+	NodeNetworkStateLayoutPerInterface NodeNetworkStateLayout = "PerInterface"
+	// NodeNetworkStateLayoutPerInterfaceWithAggregate reports the interfaces at NodeNetworkInterfaces
+	// but also keeps the whole state at the NodeNetworkState for compatibility
+	NodeNetworkStateLayoutPerInterfaceWithAggregate NodeNetworkStateLayout = "PerInterfaceWithAggregate"
+)
+
+// HasNodeNetworkInterfaces returns true if the layout reports NodeNetworkInterfaces
+func (l NodeNetworkStateLayout) HasNodeNetworkInterfaces() bool {
+	return l == NodeNetworkStateLayoutPerInterface || l == NodeNetworkStateLayoutPerInterfaceWithAggregate
+}
+
+const (
+	NodeNetworkInterfaceNodeLabel = "nmstate.io/node"
+)
+
+// NodeNetworkInterfaceStatus is the status of one interface of a specific node
+type NodeNetworkInterfaceStatus struct {
+	NodeName      string `json:"nodeName,omitempty"`
+	InterfaceName string `json:"interfaceName,omitempty"`
+	InterfaceType string `json:"interfaceType,omitempty"`
+	// +kubebuilder:validation:XPreserveUnknownFields
+	CurrentState             State       `json:"currentState,omitempty"`
+	LastSuccessfulUpdateTime metav1.Time `json:"lastSuccessfulUpdateTime,omitempty"`
+}
@@ -29,6 +29,9 @@ type NodeNetworkStateStatus struct {
 	HostNetworkManagerVersion    string      `json:"hostNetworkManagerVersion,omitempty"`
 	HandlerNetworkManagerVersion string      `json:"handlerNetworkManagerVersion,omitempty"`
 	HandlerNmstateVersion        string      `json:"handlerNmstateVersion,omitempty"`
+	// LastChangeTime is the last time every top level section of the current state
+	// (interfaces, routes, dns-resolver...) changed, keyed by section name
+	LastChangeTime map[string]metav1.Time `json:"lastChangeTime,omitempty"`
 
 	Conditions ConditionList `json:"conditions,omitempty" optional:"true"`
 }
@@ -36,9 +39,33 @@ type NodeNetworkStateStatus struct {
 const (
 	NodeNetworkStateConditionAvailable ConditionType = "Available"
 	NodeNetworkStateConditionFailing   ConditionType = "Failing"
+	// NodeNetworkStateConditionExternallyModified is true if the last change
+	// of the node network state was not done by a NodeNetworkConfigurationPolicy
+	NodeNetworkStateConditionExternallyModified ConditionType = "ExternallyModified"
+
+	// The health conditions are true if the node network state has the issue
+	NodeNetworkStateConditionDefaultRouteMissing ConditionType = "DefaultRouteMissing"
+	NodeNetworkStateConditionDNSUnconfigured     ConditionType = "DNSUnconfigured"
+	NodeNetworkStateConditionBondDegraded        ConditionType = "BondDegraded"
+	NodeNetworkStateConditionInterfaceDown       ConditionType = "InterfaceDown"
+	NodeNetworkStateConditionDuplicateAddress    ConditionType = "DuplicateAddress"
 )
 
+// NodeNetworkStateHealthConditions are the conditions calculated from the
+// node network state to report its health
+var NodeNetworkStateHealthConditions = []ConditionType{
+	NodeNetworkStateConditionDefaultRouteMissing,
+	NodeNetworkStateConditionDNSUnconfigured,
+	NodeNetworkStateConditionBondDegraded,
+	NodeNetworkStateConditionInterfaceDown,
+	NodeNetworkStateConditionDuplicateAddress,
+}
+
 const (
 	NodeNetworkStateConditionFailedToConfigure      ConditionReason = "FailedToConfigure"
 	NodeNetworkStateConditionSuccessfullyConfigured ConditionReason = "SuccessfullyConfigured"
+	NodeNetworkStateConditionExternalChangeDetected ConditionReason = "ExternalChangeDetected"
+	NodeNetworkStateConditionPolicyApplied          ConditionReason = "PolicyApplied"
+	NodeNetworkStateConditionHealthCheckFailed      ConditionReason = "HealthCheckFailed"
+	NodeNetworkStateConditionHealthCheckPassed      ConditionReason = "HealthCheckPassed"
 )
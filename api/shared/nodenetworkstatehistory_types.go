@@ -0,0 +1,36 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// NodeNetworkStateHistoryLabel marks the NodeNetworkState history ConfigMaps
+const NodeNetworkStateHistoryLabel = "nmstate.io/node-network-state-history"
+
+// NodeNetworkStateHistory configures the bounded per node log of NodeNetworkState
+// changes, it is stored at the "<node>-nns-history" ConfigMap of the handler namespace.
+type NodeNetworkStateHistory struct {
+	// Size is the maximum number of changes kept per node, the oldest ones are
+	// dropped first.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50
+	// +optional
+	Size int32 `json:"size,omitempty"`
+	// TTL is how long a change is kept, changes older than it are dropped.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
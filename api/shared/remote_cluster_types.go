@@ -0,0 +1,36 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+// RemoteCluster is a cluster kubernetes-nmstate is deployed into instead of
+// the cluster running the operator, like the guest cluster of a hosted
+// control plane.
+type RemoteCluster struct {
+	// KubeconfigSecret is the Secret with the kubeconfig to access the cluster.
+	KubeconfigSecret KubeconfigSecretReference `json:"kubeconfigSecret"`
+}
+
+// KubeconfigSecretReference selects the kubeconfig key of a Secret at the
+// namespace of the object referencing it
+type KubeconfigSecretReference struct {
+	// Name of the Secret
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Key of the kubeconfig at the Secret data, kubeconfig if not specified.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
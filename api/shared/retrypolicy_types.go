@@ -0,0 +1,44 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// RetryPolicy tunes how the handler retries a failed
+// NodeNetworkConfigurationPolicy apply, the retries are spaced by an
+// exponential backoff from InitialBackoff up to MaxBackoff.
+// +kubebuilder:validation:XValidation:rule="!has(self.initialBackoff) || !has(self.maxBackoff) || duration(self.initialBackoff) <= duration(self.maxBackoff)",message="initialBackoff must not exceed maxBackoff"
+//
+//nolint:lll
+type RetryPolicy struct {
+	// MaxRetries is the number of failed applies before the enactment is
+	// marked as failed.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+	// InitialBackoff is the wait before the first retry, it has a precision of
+	// seconds.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1s')",message="initialBackoff must be at least 1s"
+	// +optional
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+	// MaxBackoff is the longest wait between two retries, it has a precision
+	// of seconds.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1s')",message="maxBackoff must be at least 1s"
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+}
@@ -0,0 +1,33 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+// Tracing configures the OpenTelemetry tracing of the handler, the spans are
+// exported to an OTLP gRPC collector.
+type Tracing struct {
+	// Endpoint is the host:port of the OTLP gRPC collector, for example
+	// "otel-collector.observability.svc:4317". Tracing is disabled if empty.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Insecure disables the TLS of the connection to the collector.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+	// SamplingPercentage is the percentage of the traces exported. Defaults to 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SamplingPercentage *int32 `json:"samplingPercentage,omitempty"`
+}
@@ -0,0 +1,45 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+// UninstallConfiguration configures the cleanup done when the NMState is
+// deleted. The NodeNetworkConfigurationEnactments, NodeNetworkStates, webhook
+// configurations, network policies and console plugin registration are always
+// removed.
+type UninstallConfiguration struct {
+	// WaitForProgressingPolicies holds the uninstall while a
+	// NodeNetworkConfigurationPolicy is progressing, so no node is left in the
+	// middle of a configuration.
+	// +optional
+	WaitForProgressingPolicies bool `json:"waitForProgressingPolicies,omitempty"`
+	// RemoveCRDs removes the NodeNetworkConfigurationPolicy,
+	// NodeNetworkConfigurationEnactment, NodeNetworkState and
+	// NodeNetworkInterface CRDs, and with them every policy.
+	// +optional
+	RemoveCRDs bool `json:"removeCRDs,omitempty"`
+}
+
+// UninstallStatus is the progress of the cleanup done when the NMState is
+// deleted
+type UninstallStatus struct {
+	// Removed lists what the uninstall removed
+	// +optional
+	Removed []string `json:"removed,omitempty"`
+	// Message explains why the uninstall is waiting
+	// +optional
+	Message string `json:"message,omitempty"`
+}
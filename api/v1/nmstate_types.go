@@ -68,6 +68,86 @@ type NMStateSpec struct {
 	// +kubebuilder:validation:Enum=info;debug
 	// +optional
 	LogLevel shared.LogLevel `json:"logLevel,omitempty"`
+	// InterfaceFilter is an optional filter of the interfaces reported at NodeNetworkState
+	// and at the network interface metrics. Unmanaged veth interfaces are always filtered out.
+	// +optional
+	InterfaceFilter *shared.InterfaceFilter `json:"interfaceFilter,omitempty"`
+	// NodeNetworkStateLayout defines how the node network state is reported. "Aggregate" (default)
+	// reports it at the NodeNetworkState, "PerInterface" reports every interface at its own
+	// NodeNetworkInterface and "PerInterfaceWithAggregate" reports both for compatibility.
+	// +kubebuilder:validation:Enum=Aggregate;PerInterface;PerInterfaceWithAggregate
+	// +optional
+	NodeNetworkStateLayout shared.NodeNetworkStateLayout `json:"nodeNetworkStateLayout,omitempty"`
+	// NodeNetworkStateHistory enables a bounded per node log of the NodeNetworkState
+	// changes with their timestamp, cause and diff. It is disabled if not specified.
+	// +optional
+	NodeNetworkStateHistory *shared.NodeNetworkStateHistory `json:"nodeNetworkStateHistory,omitempty"`
+	// InterfaceMetrics enables the per interface link health metrics labeled by
+	// node and interface. They are disabled if not specified.
+	// +optional
+	InterfaceMetrics *shared.InterfaceMetrics `json:"interfaceMetrics,omitempty"`
+	// Tracing exports OpenTelemetry spans of the NodeNetworkConfigurationPolicy
+	// reconcile, apply and probes to an OTLP collector. It is disabled if not specified.
+	// +optional
+	Tracing *shared.Tracing `json:"tracing,omitempty"`
+	// Monitoring configures the alerts of the PrometheusRule rendered when the
+	// Prometheus operator is installed.
+	// +optional
+	Monitoring *shared.Monitoring `json:"monitoring,omitempty"`
+	// EnactmentTranscripts captures the apply, probes and rollback transcript of
+	// every enactment attempt into a ConfigMap. It is disabled if not specified.
+	// +optional
+	EnactmentTranscripts *shared.EnactmentTranscripts `json:"enactmentTranscripts,omitempty"`
+	// Components overrides the resources, priority class, image, pull secrets
+	// and environment of the handler, webhook and metrics pods.
+	// +optional
+	Components *shared.Components `json:"components,omitempty"`
+	// HandlerPools deploys a dedicated handler DaemonSet per pool with its own
+	// node selector and tuning, the nodes of a pool are not served by the
+	// default handler DaemonSet. A node matching several pools belongs to the
+	// first one listed.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	HandlerPools []NMStateHandlerPool `json:"handlerPools,omitempty"`
+	// ReconcileConfiguration tunes the retries and backoff of the failed
+	// NodeNetworkConfigurationPolicy applies, a policy can override it at
+	// spec.retryPolicy. The operator NNCP_MAX_RETRIES, NNCP_MAX_BACKOFF_SECONDS
+	// and NNCP_INITIAL_BACKOFF_SECONDS are used for the fields not specified.
+	// +optional
+	ReconcileConfiguration *shared.RetryPolicy `json:"reconcileConfiguration,omitempty"`
+	// Uninstall configures the cleanup done by the operator when the NMState
+	// is deleted.
+	// +optional
+	Uninstall *shared.UninstallConfiguration `json:"uninstall,omitempty"`
+	// ManifestOverlays is the ConfigMap at the operator namespace with the
+	// overlays applied to the rendered manifests, in key order. Every key is a
+	// kustomize style patch with a target, selecting the objects by group,
+	// version, kind, name and namespace, and a patch, either a strategic merge
+	// patch or a JSON 6902 patch list. The target of a strategic merge patch
+	// defaults to the object in it. The RBAC Roles, ClusterRoles and their
+	// bindings cannot be patched. The manifests are not applied while an
+	// overlay is invalid or fails.
+	// +optional
+	ManifestOverlays *corev1.LocalObjectReference `json:"manifestOverlays,omitempty"`
+	// Availability configures the PodDisruptionBudgets and the topology spread
+	// of the webhook, metrics and console plugin Deployments. They are
+	// reconciled as the number of infra nodes changes.
+	// +optional
+	Availability *shared.Availability `json:"availability,omitempty"`
+	// RedactPaths are gjson paths of the desired and current state secrets
+	// redacted by the handler on top of the well known nmstate ones, for
+	// example "interfaces.#.my-plugin.token". A "#" path component matches
+	// every element of an array, dots at keys have to be escaped.
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:Pattern=`^[^,]+$`
+	// +optional
+	RedactPaths []string `json:"redactPaths,omitempty"`
+	// HandlerMetrics enables the handler apply and probe metrics, they are
+	// disabled by default.
+	// +optional
+	HandlerMetrics *shared.HandlerMetrics `json:"handlerMetrics,omitempty"`
 }
 
 type SelfSignConfiguration struct {
@@ -83,6 +163,39 @@ type SelfSignConfiguration struct {
 	CertOverlapInterval string `json:"certOverlapInterval,omitempty"`
 }
 
+// NMStateHandlerPool configures the handler DaemonSet of a group of nodes,
+// the fields not specified are taken from the NMState spec.
+type NMStateHandlerPool struct {
+	// Name of the pool, it suffixes the handler DaemonSet name and labels the
+	// nodes of the pool.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=32
+	// +required
+	Name string `json:"name"`
+	// NodeSelector selects the nodes of the pool, they need to have each of the
+	// indicated key-value pairs as labels.
+	// +kubebuilder:validation:MinProperties=1
+	// +required
+	NodeSelector map[string]string `json:"nodeSelector"`
+	// Tolerations of the pool handler DaemonSet.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Affinity of the pool handler DaemonSet.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// LogLevel of the pool handler.
+	// +kubebuilder:validation:Enum=info;debug
+	// +optional
+	LogLevel shared.LogLevel `json:"logLevel,omitempty"`
+	// ProbeConfiguration of the pool handler.
+	// +optional
+	ProbeConfiguration *NMStateProbeConfiguration `json:"probeConfiguration,omitempty"`
+	// ReconcileConfiguration of the pool handler, the fields not specified are
+	// taken from the NMState reconcileConfiguration.
+	// +optional
+	ReconcileConfiguration *shared.RetryPolicy `json:"reconcileConfiguration,omitempty"`
+}
+
 type NMStateProbeConfiguration struct {
 	// +kubebuilder:default={"host": "root-servers.net"}
 	DNS NMStateDNSProbeConfiguration `json:"dns,omitempty"`
@@ -106,6 +219,30 @@ type NMStateMetricsConfiguration struct {
 type NMStateStatus struct {
 	// +optional
 	Conditions shared.ConditionList `json:"conditions,omitempty"`
+	// HandlerRollouts is the progress of the operator coordinated rollout of
+	// every handler DaemonSet.
+	// +optional
+	HandlerRollouts []shared.HandlerRolloutStatus `json:"handlerRollouts,omitempty"`
+	// Components is the readiness, image and version of every Deployment and
+	// DaemonSet deployed by the operator.
+	// +optional
+	Components []shared.ComponentStatus `json:"components,omitempty"`
+	// NmstateVersions is the spread of the handler nmstate versions across
+	// the nodes, as reported at the NodeNetworkStates.
+	// +optional
+	NmstateVersions []shared.VersionCount `json:"nmstateVersions,omitempty"`
+	// NetworkManagerVersions is the spread of the host NetworkManager versions
+	// across the nodes, as reported at the NodeNetworkStates.
+	// +optional
+	NetworkManagerVersions []shared.VersionCount `json:"networkManagerVersions,omitempty"`
+	// TLSProfileWarning describes the cluster TLS profile entries the
+	// components cannot honor.
+	// +optional
+	TLSProfileWarning string `json:"tlsProfileWarning,omitempty"`
+	// Uninstall is the progress of the cleanup done when the NMState is
+	// deleted.
+	// +optional
+	Uninstall *shared.UninstallStatus `json:"uninstall,omitempty"`
 }
 
 // +genclient:nonNamespaced
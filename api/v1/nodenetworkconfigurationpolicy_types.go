@@ -39,6 +39,7 @@ type NodeNetworkConfigurationPolicyList struct {
 // +kubebuilder:resource:path=nodenetworkconfigurationpolicies,shortName=nncp,scope=Cluster
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.status==\"True\")].type",description="Status"
 // +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.status==\"True\")].reason",description="Reason"
+// +kubebuilder:printcolumn:name="Nodes",type="string",JSONPath=".status.nodes.summary",description="Nodes"
 // +kubebuilder:storageversion
 
 // NodeNetworkConfigurationPolicy is the Schema for the nodenetworkconfigurationpolicies API
@@ -0,0 +1,64 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RemoteNMStateSpec defines the desired state of RemoteNMState
+type RemoteNMStateSpec struct {
+	// RemoteCluster is the cluster kubernetes-nmstate is deployed into, its
+	// kubeconfig Secret is at the RemoteNMState namespace.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="remoteCluster is immutable"
+	RemoteCluster shared.RemoteCluster `json:"remoteCluster"`
+	// NMStateSpec configures kubernetes-nmstate at the remote cluster as the
+	// NMState does at the cluster running the operator.
+	NMStateSpec `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=remotenmstates,scope=Namespaced
+// +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".spec.remoteCluster.kubeconfigSecret.name",description="Kubeconfig Secret"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.status==\"True\")].type",description="Status"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.status==\"True\")].reason",description="Reason"
+
+// RemoteNMState deploys kubernetes-nmstate into a remote cluster, like the
+// guest cluster of a hosted control plane, from the namespace of its
+// kubeconfig Secret. There can be one per kubeconfig Secret.
+type RemoteNMState struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteNMStateSpec `json:"spec"`
+	Status NMStateStatus     `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RemoteNMStateList contains a list of RemoteNMState
+type RemoteNMStateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteNMState `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RemoteNMState{}, &RemoteNMStateList{})
+}
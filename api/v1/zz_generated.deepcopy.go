@@ -68,6 +68,50 @@ func (in *NMStateDNSProbeConfiguration) DeepCopy() *NMStateDNSProbeConfiguration
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NMStateHandlerPool) DeepCopyInto(out *NMStateHandlerPool) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProbeConfiguration != nil {
+		in, out := &in.ProbeConfiguration, &out.ProbeConfiguration
+		*out = new(NMStateProbeConfiguration)
+		**out = **in
+	}
+	if in.ReconcileConfiguration != nil {
+		in, out := &in.ReconcileConfiguration, &out.ReconcileConfiguration
+		*out = new(shared.RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NMStateHandlerPool.
+func (in *NMStateHandlerPool) DeepCopy() *NMStateHandlerPool {
+	if in == nil {
+		return nil
+	}
+	out := new(NMStateHandlerPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NMStateList) DeepCopyInto(out *NMStateList) {
 	*out = *in
@@ -179,6 +223,78 @@ func (in *NMStateSpec) DeepCopyInto(out *NMStateSpec) {
 	}
 	out.ProbeConfiguration = in.ProbeConfiguration
 	out.MetricsConfiguration = in.MetricsConfiguration
+	if in.InterfaceFilter != nil {
+		in, out := &in.InterfaceFilter, &out.InterfaceFilter
+		*out = new(shared.InterfaceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeNetworkStateHistory != nil {
+		in, out := &in.NodeNetworkStateHistory, &out.NodeNetworkStateHistory
+		*out = new(shared.NodeNetworkStateHistory)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InterfaceMetrics != nil {
+		in, out := &in.InterfaceMetrics, &out.InterfaceMetrics
+		*out = new(shared.InterfaceMetrics)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(shared.Tracing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(shared.Monitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnactmentTranscripts != nil {
+		in, out := &in.EnactmentTranscripts, &out.EnactmentTranscripts
+		*out = new(shared.EnactmentTranscripts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = new(shared.Components)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HandlerPools != nil {
+		in, out := &in.HandlerPools, &out.HandlerPools
+		*out = make([]NMStateHandlerPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReconcileConfiguration != nil {
+		in, out := &in.ReconcileConfiguration, &out.ReconcileConfiguration
+		*out = new(shared.RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Uninstall != nil {
+		in, out := &in.Uninstall, &out.Uninstall
+		*out = new(shared.UninstallConfiguration)
+		**out = **in
+	}
+	if in.ManifestOverlays != nil {
+		in, out := &in.ManifestOverlays, &out.ManifestOverlays
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Availability != nil {
+		in, out := &in.Availability, &out.Availability
+		*out = new(shared.Availability)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedactPaths != nil {
+		in, out := &in.RedactPaths, &out.RedactPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HandlerMetrics != nil {
+		in, out := &in.HandlerMetrics, &out.HandlerMetrics
+		*out = new(shared.HandlerMetrics)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NMStateSpec.
@@ -201,6 +317,31 @@ func (in *NMStateStatus) DeepCopyInto(out *NMStateStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HandlerRollouts != nil {
+		in, out := &in.HandlerRollouts, &out.HandlerRollouts
+		*out = make([]shared.HandlerRolloutStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]shared.ComponentStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.NmstateVersions != nil {
+		in, out := &in.NmstateVersions, &out.NmstateVersions
+		*out = make([]shared.VersionCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkManagerVersions != nil {
+		in, out := &in.NetworkManagerVersions, &out.NetworkManagerVersions
+		*out = make([]shared.VersionCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.Uninstall != nil {
+		in, out := &in.Uninstall, &out.Uninstall
+		*out = new(shared.UninstallStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NMStateStatus.
@@ -272,6 +413,82 @@ func (in *NodeNetworkConfigurationPolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteNMState) DeepCopyInto(out *RemoteNMState) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteNMState.
+func (in *RemoteNMState) DeepCopy() *RemoteNMState {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteNMState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteNMState) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteNMStateList) DeepCopyInto(out *RemoteNMStateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteNMState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteNMStateList.
+func (in *RemoteNMStateList) DeepCopy() *RemoteNMStateList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteNMStateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteNMStateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteNMStateSpec) DeepCopyInto(out *RemoteNMStateSpec) {
+	*out = *in
+	out.RemoteCluster = in.RemoteCluster
+	in.NMStateSpec.DeepCopyInto(&out.NMStateSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteNMStateSpec.
+func (in *RemoteNMStateSpec) DeepCopy() *RemoteNMStateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteNMStateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelfSignConfiguration) DeepCopyInto(out *SelfSignConfiguration) {
 	*out = *in
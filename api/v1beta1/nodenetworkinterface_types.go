@@ -0,0 +1,53 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=nodenetworkinterfaces,shortName=nni,scope=Cluster
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Node",type="string",JSONPath=".status.nodeName"
+// +kubebuilder:printcolumn:name="Interface",type="string",JSONPath=".status.interfaceName"
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".status.interfaceType"
+
+// NodeNetworkInterface is the Schema for the nodenetworkinterfaces API, it is
+// only reported when the NMState CR configures a per interface state layout
+type NodeNetworkInterface struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status shared.NodeNetworkInterfaceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeNetworkInterfaceList contains a list of NodeNetworkInterface
+type NodeNetworkInterfaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeNetworkInterface `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeNetworkInterface{}, &NodeNetworkInterfaceList{})
+}
@@ -25,6 +25,9 @@ import (
 
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=nodenetworkstates,shortName=nns,scope=Cluster
+// +kubebuilder:printcolumn:name="Conditions",type="string",JSONPath=".status.conditions[?(@.status==\"True\")].type",description="Conditions"
+// +kubebuilder:printcolumn:name="Updated",type="date",JSONPath=".status.lastSuccessfulUpdateTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:storageversion
 // +kubebuilder:object:root=true
 
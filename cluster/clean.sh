@@ -37,7 +37,9 @@ function clean() {
     $kubectl delete --ignore-not-found -f deploy/crds/nmstate.io_nodenetworkconfigurationenactments.yaml
     $kubectl delete --ignore-not-found -f deploy/crds/nmstate.io_nodenetworkconfigurationpolicies.yaml
     $kubectl delete --ignore-not-found -f deploy/crds/nmstate.io_nodenetworkstates.yaml
+    $kubectl delete --ignore-not-found -f deploy/crds/nmstate.io_nodenetworkinterfaces.yaml
     $kubectl delete --ignore-not-found -f deploy/crds/nmstate.io_nmstates.yaml
+    $kubectl delete --ignore-not-found -f deploy/crds/nmstate.io_remotenmstates.yaml
     $kubectl delete --ignore-not-found -f $MANIFESTS_DIR/namespace.yaml
     $kubectl delete --ignore-not-found -f $MANIFESTS_DIR/service_account.yaml
     $kubectl delete --ignore-not-found -f $MANIFESTS_DIR/role.yaml
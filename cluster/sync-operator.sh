@@ -24,6 +24,7 @@ function deploy_operator() {
     $kubectl apply -f $MANIFESTS_DIR/role.yaml
     $kubectl apply -f $MANIFESTS_DIR/role_binding.yaml
     $kubectl apply -f deploy/crds/nmstate.io_nmstates.yaml
+    $kubectl apply -f deploy/crds/nmstate.io_remotenmstates.yaml
     $kubectl apply -f $MANIFESTS_DIR/operator.yaml
 }
 
@@ -27,8 +27,9 @@ import (
 	"strconv"
 	"time"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -69,6 +70,9 @@ import (
 	nmstatelog "github.com/nmstate/kubernetes-nmstate/pkg/log"
 	"github.com/nmstate/kubernetes-nmstate/pkg/monitoring"
 	"github.com/nmstate/kubernetes-nmstate/pkg/nmstatectl"
+	"github.com/nmstate/kubernetes-nmstate/pkg/node"
+	"github.com/nmstate/kubernetes-nmstate/pkg/state"
+	"github.com/nmstate/kubernetes-nmstate/pkg/tracing"
 	"github.com/nmstate/kubernetes-nmstate/pkg/webhook"
 )
 
@@ -105,6 +109,22 @@ func init() {
 	metrics.Registry.MustRegister(monitoring.NetworkRoutes)
 	metrics.Registry.MustRegister(monitoring.PolicyStatus)
 	metrics.Registry.MustRegister(monitoring.EnactmentStatus)
+	metrics.Registry.MustRegister(monitoring.InterfaceOperState)
+	metrics.Registry.MustRegister(monitoring.InterfaceMTU)
+	metrics.Registry.MustRegister(monitoring.InterfaceSpeed)
+	metrics.Registry.MustRegister(monitoring.BondPorts)
+	metrics.Registry.MustRegister(monitoring.InterfaceLLDPNeighbors)
+	metrics.Registry.MustRegister(monitoring.InterfaceMetricsDropped)
+	metrics.Registry.MustRegister(monitoring.HealthConditions)
+	metrics.Registry.MustRegister(monitoring.StateRefreshTimestamp)
+	metrics.Registry.MustRegister(monitoring.PolicySlotPending)
+	metrics.Registry.MustRegister(monitoring.PolicySlotPendingSeconds)
+	metrics.Registry.MustRegister(monitoring.ApplyDuration)
+	metrics.Registry.MustRegister(monitoring.ProbeDuration)
+	metrics.Registry.MustRegister(monitoring.PolicyRetries)
+	metrics.Registry.MustRegister(monitoring.PolicyRollbacks)
+	metrics.Registry.MustRegister(monitoring.PolicyAborts)
+	metrics.Registry.MustRegister(monitoring.SlotConflicts)
 }
 
 func main() {
@@ -169,7 +189,7 @@ func mainHandler() int {
 	// Compose the TLS opts applied to all TLS-enabled servers.
 	tlsOpts := composeTLSOpts(platformTLSOpts)
 
-	mgr, err := createManager(cfg, tlsOpts, platformTLSOpts != nil)
+	mgr, err := createManager(cfg, tlsOpts, isOpenShift)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		return generalExitStatus
@@ -177,6 +197,15 @@ func mainHandler() int {
 
 	ctx := ctrl.SetupSignalHandler()
 
+	if environment.IsHandler() {
+		shutdownTracing := setupTracing(ctx)
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				setupLog.Error(err, "failed flushing the tracing spans")
+			}
+		}()
+	}
+
 	if err := setupControllersByEnvironment(mgr, tlsOpts); err != nil {
 		return generalExitStatus
 	}
@@ -196,6 +225,28 @@ func initializeLogging(logType string, opt *zap.Options) int {
 	return 0
 }
 
+// setupTracing exports the handler spans to the OTLP collector configured
+// by the operator, tracing failures are logged and do not stop the handler.
+func setupTracing(ctx context.Context) tracing.ShutdownFunc {
+	noop := func(context.Context) error { return nil }
+	rawTracing := environment.GetEnvVar(tracing.Tracing, "")
+	if rawTracing == "" {
+		return noop
+	}
+	tracingConfig, err := tracing.ParseTracing(rawTracing)
+	if err != nil {
+		setupLog.Error(err, "ignoring tracing configuration")
+		return noop
+	}
+	shutdown, err := tracing.Setup(ctx, tracingConfig, "nmstate-handler", environment.NodeName())
+	if err != nil {
+		setupLog.Error(err, "failed setting up tracing")
+		return noop
+	}
+	setupLog.Info("Exporting traces", "endpoint", tracingConfig.Endpoint)
+	return shutdown
+}
+
 // setupHandlerLockIfNeeded sets up handler lock if running in handler mode
 func setupHandlerLockIfNeeded() (*flock.Flock, error) {
 	if !environment.IsHandler() {
@@ -223,32 +274,33 @@ func composeTLSOpts(tlsOpts func(*tls.Config)) func(*tls.Config) {
 
 // createManager creates and configures the controller manager.
 //
-// Only the nmstate-metrics Deployment enables the metrics server: it is the
-// sole component that populates custom metrics and is wired to the
-// ServiceMonitor. All other components (handler, webhook, cert-manager)
-// disable it to avoid exposing unused HTTPS ports.
+// The nmstate-metrics Deployment enables the metrics server: it populates
+// the custom metrics and is wired to the ServiceMonitor. The handler enables
+// it only if METRICS_BIND_ADDRESS is set, to expose its apply and probe
+// metrics. All other components (webhook, cert-manager) disable it to avoid
+// exposing unused HTTPS ports.
 //
 // When the metrics server is enabled, it always uses TLS (SecureServing).
 // On non-OpenShift clusters controller-runtime auto-generates a self-signed
 // certificate. When isOpenShift is true, authentication and authorization
 // are enforced on the metrics endpoint via TokenReview/SubjectAccessReview.
 func createManager(cfg *rest.Config, tlsOpts func(*tls.Config), isOpenShift bool) (manager.Manager, error) {
-	var metricsOpts metricsserver.Options
-
+	metricsBindAddress := "0"
 	if environment.IsMetricsManager() {
-		metricsBindAddress := environment.GetEnvVar("METRICS_BIND_ADDRESS", ":8089")
-		metricsOpts = metricsserver.Options{
-			BindAddress:   metricsBindAddress,
-			SecureServing: true,
-			TLSOpts:       []func(*tls.Config){tlsOpts},
-		}
+		metricsBindAddress = environment.GetEnvVar("METRICS_BIND_ADDRESS", ":8089")
+	} else if environment.IsHandler() {
+		metricsBindAddress = environment.GetEnvVar("METRICS_BIND_ADDRESS", "0")
+	}
+
+	metricsOpts := metricsserver.Options{
+		BindAddress: metricsBindAddress,
+	}
+	if metricsBindAddress != "0" {
+		metricsOpts.SecureServing = true
+		metricsOpts.TLSOpts = []func(*tls.Config){tlsOpts}
 		if isOpenShift {
 			metricsOpts.FilterProvider = filters.WithAuthenticationAndAuthorization
 		}
-	} else {
-		metricsOpts = metricsserver.Options{
-			BindAddress: "0",
-		}
 	}
 
 	ctrlOptions := ctrl.Options{
@@ -302,15 +354,14 @@ func setupWebhookEnvironment(mgr manager.Manager, tlsOpts func(*tls.Config)) err
 	return nil
 }
 
-// setupHandlerEnvironment cleans up unavailableNodeCounts after unexpected restart,
+// setupHandlerEnvironment releases the node maxUnavailable slots after unexpected restart,
 // configures the handler controllers and performs health checks
 func setupHandlerEnvironment(mgr manager.Manager) error {
-	// Clean stale unavailable counts from node before starting controllers
-	// Prevents deadlock after unexpected cluster reboot where nodes were
-	// processing NNCP and left stale counts in etcd.
-	if err := cleanStaleUnavailableCounts(mgr); err != nil {
-		setupLog.Error(err, "Failed to cleanup stale unavailable counts, continuing anyway")
-		// Don't error this is best-effort (NNCP needs manual restart)
+	// Release the slots held by the node before starting controllers so the
+	// other nodes do not wait for them to expire after a handler restart.
+	if err := cleanStaleEnactments(mgr); err != nil {
+		setupLog.Error(err, "Failed to cleanup stale enactments, continuing anyway")
+		// Don't error this is best-effort, the slots expire anyway
 	}
 
 	if err := setupHandlerControllers(mgr); err != nil {
@@ -333,51 +384,45 @@ func startManager(mgr manager.Manager, ctx context.Context) int {
 	return 0
 }
 
-// cleanStaleUnavailableCounts cleans up stale unavailable node counts that have been
-// left in NNCP status after unexpected handler restarts or cluster reboots.
+// cleanStaleEnactments releases the maxUnavailable slots held by the node and
+// resets the retry counts left by previous interrupted reconciles.
 //
 // At handler startup, no applies are in progress for this node. Any enactment that
-// is NOT in Available=True state may have a stale UnavailableNodeCountMap entry from
-// a previous interrupted reconcile. We check !IsAvailable rather than IsProgressing
+// is NOT in Available=True state may have a stale retry count from a previous
+// interrupted reconcile. We check !IsAvailable rather than IsProgressing
 // because crashes can leave enactments in various non-progressing states (Failing,
-// Pending, empty conditions) that still have stale counts.
-func cleanStaleUnavailableCounts(mgr manager.Manager) error {
+// Pending, empty conditions).
+func cleanStaleEnactments(mgr manager.Manager) error {
 	ctx := context.Background()
 	nodeName := environment.NodeName()
-	setupLog.Info("Cleaning up stale unavailable counts for node", "node", nodeName)
+	setupLog.Info("Cleaning up stale enactments for node", "node", nodeName)
 
 	apiClient, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
 	if err != nil {
 		return err
 	}
 
+	slots := node.Slots{Client: apiClient, Namespace: environment.GetEnvVar("POD_NAMESPACE", ""), NodeName: nodeName}
+	if err := slots.ReleaseAll(ctx); err != nil {
+		setupLog.Error(err, "Failed to release stale maxUnavailable slots")
+		// no return to continue with the retry counts
+	}
+
 	enactmentList := &nmstatev1beta1.NodeNetworkConfigurationEnactmentList{}
 	nodeLabel := client.MatchingLabels{nmstateapi.EnactmentNodeLabel: nodeName}
 	if err := apiClient.List(ctx, enactmentList, nodeLabel); err != nil {
 		return err
 	}
 
-	// For each enactment that is not Available (may have a stale count from an interrupted apply)
 	for i := range enactmentList.Items {
 		enactment := &enactmentList.Items[i]
 		if !enactmentstatus.IsAvailable(&enactment.Status.Conditions) {
-			policyName := enactment.Labels[nmstateapi.EnactmentPolicyLabel]
-			if policyName == "" {
-				continue
-			}
 			generationKey := strconv.FormatInt(enactment.Status.PolicyGeneration, 10)
 
 			setupLog.Info("detected stale non-available enactment, cleaning up",
 				"enactment", enactment.Name,
-				"policy", policyName,
 				"generation", generationKey)
 
-			// Decrement counter for this policy and generation
-			if err := decrementStaleUnavailableCount(ctx, apiClient, policyName, generationKey); err != nil {
-				setupLog.Error(err, "Failed to decrement stale count", "policy", policyName)
-				// no return to continue with other enactments
-			}
-
 			// Reset retry count for this enactment and generation
 			if err := resetStaleRetryCount(ctx, apiClient, enactment.Name, generationKey); err != nil {
 				setupLog.Error(err, "Failed to reset stale retry count", "enactment", enactment.Name)
@@ -386,40 +431,10 @@ func cleanStaleUnavailableCounts(mgr manager.Manager) error {
 		}
 	}
 
-	setupLog.Info("Finished cleaning up stale unavailable counts", "node", nodeName)
+	setupLog.Info("Finished cleaning up stale enactments", "node", nodeName)
 	return nil
 }
 
-// decrementStaleUnavailableCount decrements the UnavailableNodeCountMap of a specific
-// policy and generation for startup cleanup.
-func decrementStaleUnavailableCount(ctx context.Context, cli client.Client, policyName, generationKey string) error {
-	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		policy := &nmstatev1.NodeNetworkConfigurationPolicy{}
-		if err := cli.Get(ctx, types.NamespacedName{Name: policyName}, policy); err != nil {
-			if apierrors.IsNotFound(err) {
-				setupLog.Info("Policy not found during stale count cleanup, skipping", "policy", policyName)
-				return nil
-			}
-			return err
-		}
-
-		if policy.Status.UnavailableNodeCountMap == nil {
-			return nil // Nothing to clean up
-		}
-
-		if policy.Status.UnavailableNodeCountMap[generationKey] > 0 {
-			policy.Status.UnavailableNodeCountMap[generationKey]--
-			setupLog.Info("Decremented stale unavailable count",
-				"policy", policyName,
-				"generation", generationKey,
-				"newCount", policy.Status.UnavailableNodeCountMap[generationKey])
-			return cli.Status().Update(ctx, policy)
-		}
-
-		return nil
-	})
-}
-
 // resetStaleRetryCount resets the RetryCount for a specific enactment and generation
 // during startup clean to prevent stale retry counts from previous interrupted reconciles.
 func resetStaleRetryCount(ctx context.Context, cli client.Client, enactmentName, generationKey string) error {
@@ -457,6 +472,22 @@ func cacheResourcesOnNodes(ctrlOptions *ctrl.Options) {
 			&nmstatev1beta1.NodeNetworkConfigurationEnactment{}: {
 				Label: nodeLabelMatchingNodeNameSelector,
 			},
+			&nmstatev1beta1.NodeNetworkInterface{}: {
+				Label: labels.Set{nmstateapi.NodeNetworkInterfaceNodeLabel: nodeName}.AsSelector(),
+			},
+			// The maxUnavailable slots are Leases at the handler namespace
+			&coordinationv1.Lease{}: {
+				Namespaces: map[string]cache.Config{
+					environment.GetEnvVar("POD_NAMESPACE", ""): {},
+				},
+			},
+			// Only the node NodeNetworkState history and enactment transcripts are read
+			&corev1.ConfigMap{}: {
+				Namespaces: map[string]cache.Config{
+					environment.GetEnvVar("POD_NAMESPACE", ""): {},
+				},
+				Label: labels.Set{names.NodeLabelKey: nodeName}.AsSelector(),
+			},
 		},
 	}
 }
@@ -485,16 +516,6 @@ func restrictCertManagerCache(ctrlOptions *ctrl.Options) error {
 }
 
 func setupHandlerControllers(mgr manager.Manager) error {
-	setupLog.Info("Creating Node controller")
-	if err := (&controllers.NodeReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("Node"),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create Node controller", "controller", "NMState")
-		return err
-	}
-
 	setupLog.Info("Creating non cached client")
 	apiClient, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
 	if err != nil {
@@ -502,6 +523,27 @@ func setupHandlerControllers(mgr manager.Manager) error {
 		return err
 	}
 
+	setupLog.Info("Creating Node controller")
+	if err := (&controllers.NodeReconciler{
+		Client:    mgr.GetClient(),
+		APIClient: apiClient,
+		Log:       ctrl.Log.WithName("controllers").WithName("Node"),
+		Scheme:    mgr.GetScheme(),
+		Layout: nmstateapi.NodeNetworkStateLayout(
+			environment.GetEnvVar("NNS_LAYOUT", string(nmstateapi.NodeNetworkStateLayoutAggregate)),
+		),
+		History: nmstateapi.NodeNetworkStateHistory{
+			Size: int32(environment.GetEnvVarAsInt("NNS_HISTORY_SIZE", 0)), //nolint:gosec // bounded by the NMState validation
+			TTL:  &metav1.Duration{Duration: environment.GetEnvVarAsDuration("NNS_HISTORY_TTL_SECONDS", 0)},
+		},
+		Namespace: environment.GetEnvVar("POD_NAMESPACE", ""),
+		//nolint:staticcheck // TODO: migrate to GetEventRecorder
+		Recorder: mgr.GetEventRecorderFor(fmt.Sprintf("%s.nmstate-handler", environment.NodeName())),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create Node controller", "controller", "NMState")
+		return err
+	}
+
 	setupLog.Info("Creating NodeNetworkConfigurationPolicy controller")
 	if err = (&controllers.NodeNetworkConfigurationPolicyReconciler{
 		Client:    mgr.GetClient(),
@@ -513,6 +555,12 @@ func setupHandlerControllers(mgr manager.Manager) error {
 		RetriesUntilFail:   environment.GetEnvVarAsInt("NNCP_MAX_RETRIES", defaultRetriesUntilFail),
 		MaximumTimeBackoff: environment.GetEnvVarAsDuration("NNCP_MAX_BACKOFF_SECONDS", defaultMaxBackoff),
 		InitialBackoff:     environment.GetEnvVarAsDuration("NNCP_INITIAL_BACKOFF_SECONDS", defaultInitialBackoff),
+		Transcripts: nmstateapi.EnactmentTranscripts{
+			Size:     int32(environment.GetEnvVarAsInt("NNCE_TRANSCRIPTS_SIZE", 0)),      //nolint:gosec // bounded by the NMState validation
+			MaxBytes: int32(environment.GetEnvVarAsInt("NNCE_TRANSCRIPTS_MAX_BYTES", 0)), //nolint:gosec // bounded by the NMState validation
+			TTL:      &metav1.Duration{Duration: environment.GetEnvVarAsDuration("NNCE_TRANSCRIPTS_TTL_SECONDS", 0)},
+		},
+		Namespace: environment.GetEnvVar("POD_NAMESPACE", ""),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create NodeNetworkConfigurationPolicy controller", "controller", "NMState")
 		return err
@@ -620,10 +668,19 @@ func setupMetricsManager(mgr manager.Manager) error {
 	}
 
 	setupLog.Info("Creating Metrics NodeNetworkState controller")
+	var interfaceMetrics *nmstateapi.InterfaceMetrics
+	if rawInterfaceMetrics := environment.GetEnvVar(state.InterfaceMetrics, ""); rawInterfaceMetrics != "" {
+		var err error
+		interfaceMetrics, err = state.ParseInterfaceMetrics(rawInterfaceMetrics)
+		if err != nil {
+			setupLog.Error(err, "ignoring per interface metrics configuration")
+		}
+	}
 	if err := (&controllersmetrics.NodeNetworkStateReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("metrics").WithName("NodeNetworkState"),
-		Scheme: mgr.GetScheme(),
+		Client:           mgr.GetClient(),
+		Log:              ctrl.Log.WithName("metrics").WithName("NodeNetworkState"),
+		Scheme:           mgr.GetScheme(),
+		InterfaceMetrics: interfaceMetrics,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create NodeNetworkState metrics controller", "metrics", "NMState")
 		return err
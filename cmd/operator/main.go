@@ -30,6 +30,7 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -41,10 +42,12 @@ import (
 	"github.com/kelseyhightower/envconfig"
 	"github.com/spf13/pflag"
 
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
 	nmstatev1 "github.com/nmstate/kubernetes-nmstate/api/v1"
 	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
 	controllers "github.com/nmstate/kubernetes-nmstate/controllers/operator"
 	"github.com/nmstate/kubernetes-nmstate/pkg/cluster"
+	"github.com/nmstate/kubernetes-nmstate/pkg/environment"
 )
 
 type ProfilerConfig struct {
@@ -88,6 +91,21 @@ func main() {
 			BindAddress: "0", // disable metrics
 		},
 		HealthProbeBindAddress: ":8081",
+		Cache: cache.Options{
+			// Only the manifest overlays ConfigMaps at the operator namespace are watched
+			ByObject: map[client.Object]cache.ByObject{
+				&corev1.ConfigMap{}: {
+					Namespaces: map[string]cache.Config{
+						environment.GetEnvVar("OPERATOR_NAMESPACE", ""): {},
+					},
+				},
+				// Only the versions at the NodeNetworkStates are read, the
+				// network state is dropped to keep the cache small
+				&nmstatev1beta1.NodeNetworkState{}: {
+					Transform: nodeNetworkStateVersions,
+				},
+			},
+		},
 	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrlOptions)
@@ -132,6 +150,8 @@ func setupOperatorController(mgr manager.Manager) error {
 		Log:         ctrl.Log.WithName("controllers").WithName("NMState"),
 		Scheme:      mgr.GetScheme(),
 		IsOpenShift: isOpenShift,
+		//nolint:staticcheck // TODO: migrate to GetEventRecorder
+		Recorder: mgr.GetEventRecorderFor("nmstate-operator"),
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("failed creating NMState CR controller: %w", err)
 	}
@@ -168,3 +188,19 @@ func setupHealthProbes(mgr manager.Manager) error {
 	}
 	return nil
 }
+
+// nodeNetworkStateVersions strips the cached NodeNetworkStates down to the
+// versions reported by the handlers.
+func nodeNetworkStateVersions(obj interface{}) (interface{}, error) {
+	nns, ok := obj.(*nmstatev1beta1.NodeNetworkState)
+	if !ok {
+		return obj, nil
+	}
+	nns.ManagedFields = nil
+	nns.Status = shared.NodeNetworkStateStatus{
+		HostNetworkManagerVersion:    nns.Status.HostNetworkManagerVersion,
+		HandlerNetworkManagerVersion: nns.Status.HandlerNetworkManagerVersion,
+		HandlerNmstateVersion:        nns.Status.HandlerNmstateVersion,
+	}
+	return nns, nil
+}
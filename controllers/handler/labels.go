@@ -19,4 +19,6 @@ package controllers
 
 const (
 	forceRefreshLabel = "nmstate.io/force-nns-refresh"
+	// forceRefreshPolicyAnnotation is the policy that forced the last refresh
+	forceRefreshPolicyAnnotation = "nmstate.io/force-nns-refresh-policy"
 )
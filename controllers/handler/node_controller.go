@@ -19,12 +19,18 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -36,32 +42,69 @@ import (
 	"github.com/nmstate/kubernetes-nmstate/api/shared"
 	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
 	nmstate "github.com/nmstate/kubernetes-nmstate/pkg/client"
+	"github.com/nmstate/kubernetes-nmstate/pkg/monitoring"
 	"github.com/nmstate/kubernetes-nmstate/pkg/nm"
 	"github.com/nmstate/kubernetes-nmstate/pkg/nmstatectl"
 	"github.com/nmstate/kubernetes-nmstate/pkg/node"
 	"github.com/nmstate/kubernetes-nmstate/pkg/state"
+	"github.com/nmstate/kubernetes-nmstate/pkg/truncate"
 	corev1 "k8s.io/api/core/v1"
 )
 
+const (
+	// NetworkStateExternallyModified is the reason of the events emitted when
+	// the node network state changes without a NodeNetworkConfigurationPolicy
+	NetworkStateExternallyModified = "NetworkStateExternallyModified"
+	// maxExternalChangeMessageLength bounds the event and condition messages
+	maxExternalChangeMessageLength = 1024
+	// policySettlePeriod is how long after an enactment finishes the node
+	// network state changes are still attributed to its policy, the forced
+	// refresh may race with the periodic one
+	policySettlePeriod = time.Minute
+)
+
 // Added for test purposes
 type NmstateUpdater func(
 	ctx context.Context,
 	client client.Client,
+	apiReader client.Reader,
 	node *corev1.Node,
 	observedState shared.State,
 	nns *nmstatev1beta1.NodeNetworkState,
 	versions *nmstate.DependencyVersions,
 ) error
 type NmstatectlShow func() (string, error)
+type NodeNetworkInterfaceSync func(
+	ctx context.Context,
+	client client.Client,
+	node *corev1.Node,
+	interfaces []state.InterfaceState,
+) error
 
 // NodeReconciler reconciles a Node object
 type NodeReconciler struct {
 	client.Client
-	Log            logr.Logger
-	Scheme         *runtime.Scheme
-	lastState      shared.State
-	nmstateUpdater NmstateUpdater
-	nmstatectlShow NmstatectlShow
+	// APIClient reads the NodeNetworkState from the API server when the
+	// cached one is outdated
+	APIClient client.Client
+	Log       logr.Logger
+	Scheme    *runtime.Scheme
+	// Layout defines if the interfaces are reported at NodeNetworkInterfaces
+	Layout shared.NodeNetworkStateLayout
+	// History configures the node NodeNetworkState history, it is disabled if
+	// the size is zero
+	History shared.NodeNetworkStateHistory
+	// Namespace is where the NodeNetworkState history is stored
+	Namespace string
+	// Recorder emits the events about out-of-band network changes, no events
+	// are emitted if it is nil
+	Recorder                 record.EventRecorder
+	lastState                shared.State
+	lastForceRefresh         string
+	nmstateUpdater           NmstateUpdater
+	nmstatectlShow           NmstatectlShow
+	nodeNetworkInterfaceSync NodeNetworkInterfaceSync
+	nodeNetworkInterfacesGC  bool
 }
 
 // Reconcile reads that state of the cluster for a Node object and makes changes based on the state read
@@ -90,8 +133,14 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, request ctrl.Request) (c
 			nnsInstance = nil
 		}
 	}
+	changeCause, changePolicy, err := r.changeCause(ctx, request.Name, nnsInstance)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed finding the node network state change cause")
+	}
+
 	// Reduce apiserver hits by checking node's network state with last one
 	if nnsInstance != nil && r.lastState.String() == currentState.String() {
+		r.observeRefresh(request.Name)
 		return ctrl.Result{RequeueAfter: node.NetworkStateRefreshWithJitter()}, nil
 	} else {
 		r.Log.Info("Creating/updating NodeNetworkState")
@@ -110,18 +159,274 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, request ctrl.Request) (c
 		// Error reading the object - requeue the request.
 		return ctrl.Result{}, err
 	}
-	err = r.nmstateUpdater(ctx, r.Client, nodeInstance, currentState, nnsInstance, r.getDependencyVersions())
+
+	nnsState, err := r.reportNodeNetworkInterfaces(ctx, nodeInstance, currentState)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "error at node reconcile reporting NodeNetworkInterfaces")
+	}
+
+	previousState := shared.State{}
+	if nnsInstance != nil {
+		previousState = nnsInstance.Status.CurrentState
+	}
+
+	err = r.nmstateUpdater(ctx, r.Client, r.APIClient, nodeInstance, nnsState, nnsInstance, r.getDependencyVersions())
 	if err != nil {
 		err = errors.Wrap(err, "error at node reconcile creating NodeNetworkState")
 		return ctrl.Result{}, err
 	}
 
+	// The history is informative, failing to record it should not block the reports
+	if err = r.recordHistory(ctx, nodeInstance, previousState, nnsState, changeCause, changePolicy); err != nil {
+		r.Log.Error(err, "failed recording NodeNetworkState change at history")
+	}
+
+	if err = r.reportExternalChange(ctx, nodeInstance, nnsInstance, currentState, changeCause, changePolicy); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "error at node reconcile reporting external network changes")
+	}
+
+	if err = r.reportHealth(ctx, nodeInstance, currentState); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "error at node reconcile reporting NodeNetworkState health")
+	}
+
 	// Cache currentState after successfully storing it at NodeNetworkState
 	r.lastState = currentState
+	r.observeRefresh(request.Name)
 
 	return ctrl.Result{RequeueAfter: node.NetworkStateRefreshWithJitter()}, nil
 }
 
+// reportNodeNetworkInterfaces syncs the NodeNetworkInterfaces if the layout
+// needs them and returns the state to report at the NodeNetworkState.
+func (r *NodeReconciler) reportNodeNetworkInterfaces(
+	ctx context.Context,
+	nodeInstance *corev1.Node,
+	currentState shared.State,
+) (shared.State, error) {
+	if !r.Layout.HasNodeNetworkInterfaces() {
+		// Remove leftovers from a previous per interface layout once
+		if !r.nodeNetworkInterfacesGC {
+			if err := nmstate.DeleteNodeNetworkInterfaces(ctx, r.Client, nodeInstance.Name); err != nil {
+				return currentState, err
+			}
+			r.nodeNetworkInterfacesGC = true
+		}
+		return currentState, nil
+	}
+
+	interfaces, summarizedState, err := state.SplitInterfaces(currentState)
+	if err != nil {
+		return currentState, err
+	}
+	if err = r.nodeNetworkInterfaceSync(ctx, r.Client, nodeInstance, interfaces); err != nil {
+		return currentState, err
+	}
+	if r.Layout == shared.NodeNetworkStateLayoutPerInterfaceWithAggregate {
+		return currentState, nil
+	}
+	return summarizedState, nil
+}
+
+// changeCause returns what triggered the reconcile: a policy forcing the
+// refresh after applying it, a policy being configured at the node or the
+// periodic refresh.
+func (r *NodeReconciler) changeCause(
+	ctx context.Context,
+	nodeName string,
+	nnsInstance *nmstatev1beta1.NodeNetworkState,
+) (nmstate.NodeNetworkStateChangeCause, string, error) {
+	if nnsInstance == nil {
+		return nmstate.NodeNetworkStateChangeCausePeriodicRefresh, "", nil
+	}
+	forceRefresh := nnsInstance.Labels[forceRefreshLabel]
+	defer func() { r.lastForceRefresh = forceRefresh }()
+
+	// Nothing is known about the changes done before the handler started
+	if r.lastState.Raw == nil {
+		return nmstate.NodeNetworkStateChangeCausePeriodicRefresh, "", nil
+	}
+	if forceRefresh != "" && forceRefresh != r.lastForceRefresh {
+		return nmstate.NodeNetworkStateChangeCausePolicy, nnsInstance.Annotations[forceRefreshPolicyAnnotation], nil
+	}
+	policy, err := r.configuringPolicy(ctx, nodeName)
+	if err != nil {
+		return "", "", err
+	}
+	if policy != "" {
+		return nmstate.NodeNetworkStateChangeCausePolicy, policy, nil
+	}
+	return nmstate.NodeNetworkStateChangeCauseExternal, "", nil
+}
+
+// configuringPolicy returns the policy the node is configuring or has just
+// configured, the node holds its maxUnavailable slot or its enactment is
+// progressing or finished during the last policySettlePeriod.
+func (r *NodeReconciler) configuringPolicy(ctx context.Context, nodeName string) (string, error) {
+	if r.Namespace != "" {
+		leases := coordinationv1.LeaseList{}
+		err := r.List(ctx, &leases, client.InNamespace(r.Namespace), client.MatchingLabels{shared.EnactmentNodeLabel: nodeName})
+		if err != nil {
+			return "", errors.Wrap(err, "failed listing node maxUnavailable slots")
+		}
+		for i := range leases.Items {
+			holder := leases.Items[i].Spec.HolderIdentity
+			if holder != nil && *holder == nodeName {
+				return leases.Items[i].Labels[shared.EnactmentPolicyLabel], nil
+			}
+		}
+	}
+
+	enactments := nmstatev1beta1.NodeNetworkConfigurationEnactmentList{}
+	err := r.List(ctx, &enactments, client.MatchingLabels{shared.EnactmentNodeLabel: nodeName})
+	if err != nil {
+		return "", errors.Wrap(err, "failed listing node enactments")
+	}
+	settledBefore := time.Now().Add(-policySettlePeriod)
+	for i := range enactments.Items {
+		enactment := &enactments.Items[i]
+		for _, conditionType := range []shared.ConditionType{
+			shared.NodeNetworkConfigurationEnactmentConditionProgressing,
+			shared.NodeNetworkConfigurationEnactmentConditionAvailable,
+			shared.NodeNetworkConfigurationEnactmentConditionFailing,
+		} {
+			condition := enactment.Status.Conditions.Find(conditionType)
+			if condition == nil || condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			if conditionType == shared.NodeNetworkConfigurationEnactmentConditionProgressing ||
+				condition.LastTransitionTime.After(settledBefore) {
+				return enactment.Labels[shared.EnactmentPolicyLabel], nil
+			}
+		}
+	}
+	return "", nil
+}
+
+func (r *NodeReconciler) recordHistory(
+	ctx context.Context,
+	nodeInstance *corev1.Node,
+	previousState, currentState shared.State,
+	cause nmstate.NodeNetworkStateChangeCause,
+	policy string,
+) error {
+	// There is nothing to compare with when the NodeNetworkState is created
+	if r.History.Size <= 0 || isEmptyState(previousState) || previousState.String() == currentState.String() {
+		return nil
+	}
+	sections, err := state.ChangedSections(previousState, currentState)
+	if err != nil {
+		return err
+	}
+	diff, err := state.Diff(previousState, currentState)
+	if err != nil {
+		return err
+	}
+	return nmstate.RecordNodeNetworkStateChange(ctx, r.Client, nodeInstance, r.Namespace, r.History, nmstate.NodeNetworkStateChange{
+		Timestamp: metav1.Now(),
+		Cause:     cause,
+		Policy:    policy,
+		Sections:  sections,
+		Diff:      diff,
+	})
+}
+
+// reportExternalChange emits an event at the node and sets the NodeNetworkState
+// ExternallyModified condition when the network state changed without a
+// policy, the condition is cleared the next time a policy changes it.
+func (r *NodeReconciler) reportExternalChange(
+	ctx context.Context,
+	nodeInstance *corev1.Node,
+	nnsInstance *nmstatev1beta1.NodeNetworkState,
+	currentState shared.State,
+	cause nmstate.NodeNetworkStateChangeCause,
+	policy string,
+) error {
+	switch cause {
+	case nmstate.NodeNetworkStateChangeCauseExternal:
+		message := "node network state changed"
+		changes, err := state.SummarizeChanges(r.lastState, currentState)
+		if err != nil {
+			r.Log.Error(err, "failed summarizing node network state changes")
+		} else if len(changes) > 0 {
+			message = truncate.Head(strings.Join(changes, "; "), maxExternalChangeMessageLength, "...")
+		}
+		r.Log.Info("Node network state externally modified", "changes", message)
+		if r.Recorder != nil {
+			r.Recorder.Event(nodeInstance, corev1.EventTypeWarning, NetworkStateExternallyModified, message)
+		}
+		return nmstate.SetNodeNetworkStateCondition(ctx, r.Client, nodeInstance.Name,
+			shared.NodeNetworkStateConditionExternallyModified,
+			corev1.ConditionTrue,
+			shared.NodeNetworkStateConditionExternalChangeDetected,
+			message,
+		)
+	case nmstate.NodeNetworkStateChangeCausePolicy:
+		if nnsInstance == nil {
+			return nil
+		}
+		condition := nnsInstance.Status.Conditions.Find(shared.NodeNetworkStateConditionExternallyModified)
+		if condition == nil || condition.Status != corev1.ConditionTrue {
+			return nil
+		}
+		return nmstate.SetNodeNetworkStateCondition(ctx, r.Client, nodeInstance.Name,
+			shared.NodeNetworkStateConditionExternallyModified,
+			corev1.ConditionFalse,
+			shared.NodeNetworkStateConditionPolicyApplied,
+			fmt.Sprintf("NodeNetworkConfigurationPolicy %q applied", policy),
+		)
+	}
+	return nil
+}
+
+// reportHealth sets the NodeNetworkState health conditions, the interfaces
+// configured up are the ones at the desired state of the available enactments
+// of the node.
+func (r *NodeReconciler) reportHealth(ctx context.Context, nodeInstance *corev1.Node, currentState shared.State) error {
+	enactments := nmstatev1beta1.NodeNetworkConfigurationEnactmentList{}
+	if err := r.List(ctx, &enactments, client.MatchingLabels{shared.EnactmentNodeLabel: nodeInstance.Name}); err != nil {
+		return err
+	}
+	upInterfaces := []string{}
+	for i := range enactments.Items {
+		available := enactments.Items[i].Status.Conditions.Find(shared.NodeNetworkConfigurationEnactmentConditionAvailable)
+		if available == nil || available.Status != corev1.ConditionTrue {
+			continue
+		}
+		enactmentUpInterfaces, err := state.UpInterfaces(enactments.Items[i].Status.DesiredState)
+		if err != nil {
+			return err
+		}
+		upInterfaces = append(upInterfaces, enactmentUpInterfaces...)
+	}
+
+	healthChecks, err := state.CheckHealth(currentState, upInterfaces)
+	if err != nil {
+		return err
+	}
+	conditions := shared.ConditionList{}
+	for _, healthCheck := range healthChecks {
+		status := corev1.ConditionFalse
+		reason := shared.NodeNetworkStateConditionHealthCheckPassed
+		if healthCheck.Failing {
+			status = corev1.ConditionTrue
+			reason = shared.NodeNetworkStateConditionHealthCheckFailed
+		}
+		conditions = append(conditions, shared.NewCondition(healthCheck.Type, status, reason, healthCheck.Message))
+	}
+	return nmstate.SetNodeNetworkStateConditions(ctx, r.Client, nodeInstance.Name, conditions)
+}
+
+// observeRefresh exposes when the NodeNetworkState was known to match the
+// node network state so stale handlers can be alerted.
+func (r *NodeReconciler) observeRefresh(nodeName string) {
+	monitoring.StateRefreshTimestamp.WithLabelValues(nodeName).SetToCurrentTime()
+}
+
+func isEmptyState(currentState shared.State) bool {
+	raw := strings.TrimSpace(currentState.String())
+	return raw == "" || raw == "null" || raw == "{}"
+}
+
 func (r *NodeReconciler) getDependencyVersions() *nmstate.DependencyVersions {
 	handlerNmstateVersion, err := nmstate.ExecuteCommand("nmstatectl", "--version")
 	if err != nil {
@@ -142,6 +447,7 @@ func (r *NodeReconciler) getDependencyVersions() *nmstate.DependencyVersions {
 func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.nmstateUpdater = nmstate.CreateOrUpdateNodeNetworkState
 	r.nmstatectlShow = nmstatectl.Show
+	r.nodeNetworkInterfaceSync = nmstate.SyncNodeNetworkInterfaces
 
 	// By default all this functors return true so controller watch all events,
 	// but we only want to watch create/delete for current node.
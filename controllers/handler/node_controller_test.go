@@ -20,21 +20,25 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	nmstate "github.com/nmstate/kubernetes-nmstate/pkg/client"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
 
 	"github.com/nmstate/kubernetes-nmstate/api/shared"
 	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
@@ -77,18 +81,24 @@ var _ = Describe("Node controller reconcile", func() {
 		s := scheme.Scheme
 		s.AddKnownTypes(nmstatev1beta1.GroupVersion,
 			&nmstatev1beta1.NodeNetworkState{},
+			&nmstatev1beta1.NodeNetworkInterface{},
+			&nmstatev1beta1.NodeNetworkInterfaceList{},
+			&nmstatev1beta1.NodeNetworkConfigurationEnactment{},
+			&nmstatev1beta1.NodeNetworkConfigurationEnactmentList{},
 		)
 
 		objs := []runtime.Object{&node, &nodenetworkstate}
 
 		// Create a fake client to mock API calls.
-		cl = fake.NewClientBuilder().WithScheme(s).WithStatusSubresource(&nodenetworkstate).WithRuntimeObjects(objs...).Build()
+		cl = fake.NewClientBuilder().WithScheme(s).WithStatusSubresource(&nodenetworkstate, &nmstatev1beta1.NodeNetworkInterface{}).WithRuntimeObjects(objs...).Build()
 
 		reconciler.Client = cl
+		reconciler.APIClient = cl
 		reconciler.Log = ctrl.Log.WithName("controllers").WithName("Node")
 		reconciler.Scheme = s
 		reconciler.nmstateUpdater = nmstate.CreateOrUpdateNodeNetworkState
 		reconciler.nmstatectlShow = nmstatectl.Show
+		reconciler.nodeNetworkInterfaceSync = nmstate.SyncNodeNetworkInterfaces
 		reconciler.lastState = shared.NewState("lastState")
 		observedState = `
 ---
@@ -131,7 +141,7 @@ routes:
 			By("Set last state")
 			reconciler.lastState = filteredOutObservedState
 
-			reconciler.nmstateUpdater = func(context.Context, client.Client, *corev1.Node,
+			reconciler.nmstateUpdater = func(context.Context, client.Client, client.Reader, *corev1.Node,
 				shared.State, *nmstatev1beta1.NodeNetworkState, *nmstate.DependencyVersions) error {
 				return fmt.Errorf("we are not suppose to catch this error")
 			}
@@ -202,6 +212,362 @@ routes:
 				Expect(obtainedNNS.Status.CurrentState.String()).To(Equal(filteredOutExpectedState.String()))
 			})
 		})
+		Context("and the state layout is per interface", func() {
+			var (
+				expectedStateRaw = `---
+interfaces:
+  - name: eth1
+    type: ethernet
+    state: up
+    mtu: 1500
+  - name: br-ex
+    type: ovs-bridge
+    state: up
+  - name: br-ex
+    type: ovs-interface
+    state: up
+routes:
+  running: []
+  config: []
+`
+				listNNIs = func() []nmstatev1beta1.NodeNetworkInterface {
+					nnis := nmstatev1beta1.NodeNetworkInterfaceList{}
+					ExpectWithOffset(1, cl.List(context.TODO(), &nnis)).To(Succeed())
+					return nnis.Items
+				}
+			)
+			BeforeEach(func() {
+				reconciler.Layout = shared.NodeNetworkStateLayoutPerInterface
+				reconciler.nmstatectlShow = func() (string, error) {
+					return expectedStateRaw, nil
+				}
+			})
+			It("should report every interface at a NodeNetworkInterface and summarize them at the NodeNetworkState", func() {
+				_, err := reconciler.Reconcile(context.Background(), request)
+				Expect(err).ToNot(HaveOccurred())
+
+				nnis := listNNIs()
+				Expect(nnis).To(HaveLen(3))
+				nniNames := []string{}
+				for _, nni := range nnis {
+					nniNames = append(nniNames, nni.Name)
+					Expect(nni.Labels).To(HaveKeyWithValue(shared.NodeNetworkInterfaceNodeLabel, existingNodeName))
+					Expect(nni.Status.NodeName).To(Equal(existingNodeName))
+				}
+				Expect(nniNames).To(ConsistOf("node01.eth1.ethernet", "node01.br-ex.ovs-bridge", "node01.br-ex.ovs-interface"))
+
+				obtainedNNS := nmstatev1beta1.NodeNetworkState{}
+				err = cl.Get(context.TODO(), types.NamespacedName{Name: existingNodeName}, &obtainedNNS)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(obtainedNNS.Status.CurrentState).To(MatchYAML(`interfaces:
+- name: eth1
+  type: ethernet
+  state: up
+- name: br-ex
+  type: ovs-bridge
+  state: up
+- name: br-ex
+  type: ovs-interface
+  state: up
+routes:
+  running: []
+  config: []
+`))
+			})
+			It("should only update changed interfaces and remove the gone ones", func() {
+				_, err := reconciler.Reconcile(context.Background(), request)
+				Expect(err).ToNot(HaveOccurred())
+				resourceVersions := map[string]string{}
+				for _, nni := range listNNIs() {
+					resourceVersions[nni.Name] = nni.ResourceVersion
+				}
+
+				reconciler.nmstatectlShow = func() (string, error) {
+					return `---
+interfaces:
+  - name: eth1
+    type: ethernet
+    state: up
+    mtu: 9000
+  - name: br-ex
+    type: ovs-bridge
+    state: up
+routes:
+  running: []
+  config: []
+`, nil
+				}
+				_, err = reconciler.Reconcile(context.Background(), request)
+				Expect(err).ToNot(HaveOccurred())
+
+				nnis := listNNIs()
+				Expect(nnis).To(HaveLen(2))
+				for _, nni := range nnis {
+					if nni.Name == "node01.br-ex.ovs-bridge" {
+						Expect(nni.ResourceVersion).To(Equal(resourceVersions[nni.Name]))
+					} else {
+						Expect(nni.ResourceVersion).ToNot(Equal(resourceVersions[nni.Name]))
+					}
+				}
+			})
+			Context("and then back to aggregate", func() {
+				It("should remove the NodeNetworkInterfaces", func() {
+					_, err := reconciler.Reconcile(context.Background(), request)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(listNNIs()).ToNot(BeEmpty())
+
+					reconciler.Layout = shared.NodeNetworkStateLayoutAggregate
+					reconciler.lastState = shared.NewState("lastState")
+					_, err = reconciler.Reconcile(context.Background(), request)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(listNNIs()).To(BeEmpty())
+				})
+			})
+		})
+		Context("and the NodeNetworkState history is enabled", func() {
+			var (
+				showInterfaceState = func(ifaceState string) {
+					reconciler.nmstatectlShow = func() (string, error) {
+						return fmt.Sprintf(`---
+interfaces:
+  - name: eth1
+    type: ethernet
+    state: %s
+`, ifaceState), nil
+					}
+				}
+				readHistory = func() []nmstate.NodeNetworkStateChange {
+					historyConfigMap := corev1.ConfigMap{}
+					ExpectWithOffset(1, cl.Get(context.TODO(), types.NamespacedName{
+						Namespace: "nmstate",
+						Name:      nmstate.NodeNetworkStateHistoryName(existingNodeName),
+					}, &historyConfigMap)).To(Succeed())
+					changes := []nmstate.NodeNetworkStateChange{}
+					for _, entry := range historyConfigMap.Data {
+						change := nmstate.NodeNetworkStateChange{}
+						ExpectWithOffset(1, yaml.Unmarshal([]byte(entry), &change)).To(Succeed())
+						changes = append(changes, change)
+					}
+					return changes
+				}
+			)
+			BeforeEach(func() {
+				reconciler.History = shared.NodeNetworkStateHistory{Size: 5}
+				reconciler.Namespace = "nmstate"
+
+				By("Report an initial state")
+				showInterfaceState("up")
+				_, err := reconciler.Reconcile(context.Background(), request)
+				Expect(err).ToNot(HaveOccurred())
+			})
+			It("should record an external change detected by the periodic refresh", func() {
+				showInterfaceState("down")
+				_, err := reconciler.Reconcile(context.Background(), request)
+				Expect(err).ToNot(HaveOccurred())
+
+				changes := readHistory()
+				Expect(changes).To(HaveLen(1))
+				Expect(changes[0].Cause).To(Equal(nmstate.NodeNetworkStateChangeCauseExternal))
+				Expect(changes[0].Sections).To(Equal([]string{"interfaces"}))
+				Expect(changes[0].Diff).To(ContainSubstring("-  state: up"))
+				Expect(changes[0].Diff).To(ContainSubstring("+  state: down"))
+			})
+			It("should record the policy that forced the refresh", func() {
+				nns := nmstatev1beta1.NodeNetworkState{}
+				Expect(cl.Get(context.TODO(), types.NamespacedName{Name: existingNodeName}, &nns)).To(Succeed())
+				nns.Labels = map[string]string{forceRefreshLabel: "1"}
+				nns.Annotations = map[string]string{forceRefreshPolicyAnnotation: "policy1"}
+				Expect(cl.Update(context.TODO(), &nns)).To(Succeed())
+
+				showInterfaceState("down")
+				_, err := reconciler.Reconcile(context.Background(), request)
+				Expect(err).ToNot(HaveOccurred())
+
+				changes := readHistory()
+				Expect(changes).To(HaveLen(1))
+				Expect(changes[0].Cause).To(Equal(nmstate.NodeNetworkStateChangeCausePolicy))
+				Expect(changes[0].Policy).To(Equal("policy1"))
+			})
+		})
+		Context("and the node network state health is reported", func() {
+			BeforeEach(func() {
+				reconciler.nmstatectlShow = func() (string, error) {
+					return `---
+interfaces:
+  - name: eth1
+    type: ethernet
+    state: down
+routes:
+  config: []
+  running:
+  - destination: 0.0.0.0/0
+    next-hop-interface: eth1
+`, nil
+				}
+				enactment := nmstatev1beta1.NodeNetworkConfigurationEnactment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   shared.EnactmentKey(existingNodeName, "policy1").Name,
+						Labels: map[string]string{shared.EnactmentNodeLabel: existingNodeName},
+					},
+				}
+				Expect(cl.Create(context.TODO(), &enactment)).To(Succeed())
+				enactment.Status.DesiredState = shared.NewState(`
+interfaces:
+  - name: eth1
+    type: ethernet
+    state: up
+`)
+				enactment.Status.Conditions.Set(
+					shared.NodeNetworkConfigurationEnactmentConditionAvailable, corev1.ConditionTrue, "SuccessfullyConfigured", "")
+				Expect(cl.Update(context.TODO(), &enactment)).To(Succeed())
+			})
+			It("should set the health conditions", func() {
+				_, err := reconciler.Reconcile(context.Background(), request)
+				Expect(err).ToNot(HaveOccurred())
+
+				nns := nmstatev1beta1.NodeNetworkState{}
+				Expect(cl.Get(context.TODO(), types.NamespacedName{Name: existingNodeName}, &nns)).To(Succeed())
+				for _, conditionType := range shared.NodeNetworkStateHealthConditions {
+					Expect(nns.Status.Conditions.Find(conditionType)).ToNot(BeNil(), string(conditionType))
+				}
+				interfaceDown := nns.Status.Conditions.Find(shared.NodeNetworkStateConditionInterfaceDown)
+				Expect(interfaceDown.Status).To(Equal(corev1.ConditionTrue))
+				Expect(interfaceDown.Reason).To(Equal(shared.NodeNetworkStateConditionHealthCheckFailed))
+				Expect(interfaceDown.Message).To(Equal("interfaces configured up are down: eth1"))
+				defaultRouteMissing := nns.Status.Conditions.Find(shared.NodeNetworkStateConditionDefaultRouteMissing)
+				Expect(defaultRouteMissing.Status).To(Equal(corev1.ConditionFalse))
+				Expect(defaultRouteMissing.Reason).To(Equal(shared.NodeNetworkStateConditionHealthCheckPassed))
+			})
+		})
+		Context("and the node network state is externally modified", func() {
+			var (
+				recorder           *record.FakeRecorder
+				showInterfaceState = func(ifaceState string) {
+					reconciler.nmstatectlShow = func() (string, error) {
+						return fmt.Sprintf(`---
+interfaces:
+  - name: eth1
+    type: ethernet
+    state: %s
+`, ifaceState), nil
+					}
+				}
+				externallyModifiedCondition = func() *shared.Condition {
+					nns := nmstatev1beta1.NodeNetworkState{}
+					ExpectWithOffset(1, cl.Get(context.TODO(), types.NamespacedName{Name: existingNodeName}, &nns)).To(Succeed())
+					return nns.Status.Conditions.Find(shared.NodeNetworkStateConditionExternallyModified)
+				}
+			)
+			BeforeEach(func() {
+				recorder = record.NewFakeRecorder(10)
+				reconciler.Recorder = recorder
+				reconciler.lastState = shared.State{}
+
+				By("Report an initial state")
+				showInterfaceState("up")
+				_, err := reconciler.Reconcile(context.Background(), request)
+				Expect(err).ToNot(HaveOccurred())
+
+				showInterfaceState("down")
+				_, err = reconciler.Reconcile(context.Background(), request)
+				Expect(err).ToNot(HaveOccurred())
+			})
+			It("should emit an event summarizing the change and set the ExternallyModified condition", func() {
+				Expect(recorder.Events).To(Receive(Equal(
+					"Warning NetworkStateExternallyModified eth1 changed state from up to down",
+				)))
+				condition := externallyModifiedCondition()
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+				Expect(condition.Reason).To(Equal(shared.NodeNetworkStateConditionExternalChangeDetected))
+				Expect(condition.Message).To(Equal("eth1 changed state from up to down"))
+			})
+			It("should clear the ExternallyModified condition when a policy changes the state", func() {
+				nns := nmstatev1beta1.NodeNetworkState{}
+				Expect(cl.Get(context.TODO(), types.NamespacedName{Name: existingNodeName}, &nns)).To(Succeed())
+				nns.Labels = map[string]string{forceRefreshLabel: "1"}
+				nns.Annotations = map[string]string{forceRefreshPolicyAnnotation: "policy1"}
+				Expect(cl.Update(context.TODO(), &nns)).To(Succeed())
+
+				showInterfaceState("up")
+				_, err := reconciler.Reconcile(context.Background(), request)
+				Expect(err).ToNot(HaveOccurred())
+
+				condition := externallyModifiedCondition()
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(shared.NodeNetworkStateConditionPolicyApplied))
+			})
+			It("should not report the changes done while the node configures a policy", func() {
+				Expect(recorder.Events).To(Receive())
+				Expect(cl.Create(context.TODO(), &nmstatev1beta1.NodeNetworkConfigurationEnactment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   shared.EnactmentKey(existingNodeName, "policy1").Name,
+						Labels: map[string]string{shared.EnactmentNodeLabel: existingNodeName, shared.EnactmentPolicyLabel: "policy1"},
+					},
+					Status: shared.NodeNetworkConfigurationEnactmentStatus{
+						Conditions: shared.ConditionList{{
+							Type:               shared.NodeNetworkConfigurationEnactmentConditionProgressing,
+							Status:             corev1.ConditionTrue,
+							LastTransitionTime: metav1.Now(),
+						}},
+					},
+				})).To(Succeed())
+
+				showInterfaceState("up")
+				_, err := reconciler.Reconcile(context.Background(), request)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(recorder.Events).ToNot(Receive())
+				condition := externallyModifiedCondition()
+				Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(shared.NodeNetworkStateConditionPolicyApplied))
+			})
+			It("should not report the changes done while the node holds a maxUnavailable slot", func() {
+				Expect(recorder.Events).To(Receive())
+				reconciler.Namespace = "nmstate"
+				holder := existingNodeName
+				Expect(cl.Create(context.TODO(), &coordinationv1.Lease{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      nmstatenode.SlotLeaseName("policy1", 0),
+						Namespace: reconciler.Namespace,
+						Labels:    map[string]string{shared.EnactmentNodeLabel: existingNodeName, shared.EnactmentPolicyLabel: "policy1"},
+					},
+					Spec: coordinationv1.LeaseSpec{HolderIdentity: &holder},
+				})).To(Succeed())
+
+				showInterfaceState("up")
+				_, err := reconciler.Reconcile(context.Background(), request)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(recorder.Events).ToNot(Receive())
+				Expect(externallyModifiedCondition().Status).To(Equal(corev1.ConditionFalse))
+			})
+			It("should report the changes done once the policy enactment has settled", func() {
+				Expect(recorder.Events).To(Receive())
+				Expect(cl.Create(context.TODO(), &nmstatev1beta1.NodeNetworkConfigurationEnactment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   shared.EnactmentKey(existingNodeName, "policy1").Name,
+						Labels: map[string]string{shared.EnactmentNodeLabel: existingNodeName, shared.EnactmentPolicyLabel: "policy1"},
+					},
+					Status: shared.NodeNetworkConfigurationEnactmentStatus{
+						Conditions: shared.ConditionList{{
+							Type:               shared.NodeNetworkConfigurationEnactmentConditionAvailable,
+							Status:             corev1.ConditionTrue,
+							LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * policySettlePeriod)),
+						}},
+					},
+				})).To(Succeed())
+
+				showInterfaceState("up")
+				_, err := reconciler.Reconcile(context.Background(), request)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(recorder.Events).To(Receive(Equal(
+					"Warning NetworkStateExternallyModified eth1 changed state from down to up",
+				)))
+			})
+		})
 		Context("and nodenetworkstate is not there", func() {
 			BeforeEach(func() {
 				By("Delete the nodenetworkstate")
@@ -27,8 +27,8 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/client-go/tools/record"
-	"k8s.io/client-go/util/retry"
 	"k8s.io/client-go/util/workqueue"
 
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -55,15 +55,20 @@ import (
 	"github.com/nmstate/kubernetes-nmstate/pkg/enactmentstatus"
 	enactmentconditions "github.com/nmstate/kubernetes-nmstate/pkg/enactmentstatus/conditions"
 	"github.com/nmstate/kubernetes-nmstate/pkg/environment"
+	"github.com/nmstate/kubernetes-nmstate/pkg/monitoring"
 	"github.com/nmstate/kubernetes-nmstate/pkg/nmpolicy"
 	"github.com/nmstate/kubernetes-nmstate/pkg/nmstatectl"
 	"github.com/nmstate/kubernetes-nmstate/pkg/node"
 	"github.com/nmstate/kubernetes-nmstate/pkg/policyconditions"
 	"github.com/nmstate/kubernetes-nmstate/pkg/selectors"
+	"github.com/nmstate/kubernetes-nmstate/pkg/tracing"
 )
 
 const (
 	ReconcileFailed = "ReconcileFailed"
+	// SlotLost is the reason of the events emitted when the node configured a
+	// policy after its maxUnavailable slot was taken by another node
+	SlotLost = "MaxUnavailableSlotLost"
 )
 
 var (
@@ -109,15 +114,25 @@ type NodeNetworkConfigurationPolicyReconciler struct {
 	Log       logr.Logger
 	Scheme    *runtime.Scheme
 	Recorder  record.EventRecorder
-	// RetriesUntilFail is the number of retry attempts before marking an NNCE as failed.
+	// RetriesUntilFail is the number of retry attempts before marking an NNCE as failed,
+	// a policy can override it at spec.retryPolicy.
 	// Expected range: >= 1. Defaults to 5 via NNCP_MAX_RETRIES env var.
 	RetriesUntilFail int
-	// MaximumTimeBackoff is the upper bound for exponential backoff between retries.
+	// MaximumTimeBackoff is the upper bound for exponential backoff between retries,
+	// a policy can override it at spec.retryPolicy.
 	// Expected range: > 0. Defaults to 30s via NNCP_MAX_BACKOFF_SECONDS env var.
 	MaximumTimeBackoff time.Duration
-	// InitialBackoff is the starting backoff duration for the first retry.
+	// InitialBackoff is the starting backoff duration for the first retry, a
+	// policy can override it at spec.retryPolicy.
 	// Expected range: > 0. Defaults to 1s via NNCP_INITIAL_BACKOFF_SECONDS env var.
 	InitialBackoff time.Duration
+	// Transcripts configures the enactment attempt transcripts, they are
+	// disabled if the size is zero
+	Transcripts nmstateapi.EnactmentTranscripts
+	// Namespace is where the enactment transcripts and the maxUnavailable slots are stored
+	Namespace string
+
+	slotWaits slotWaits
 }
 
 func init() {
@@ -136,9 +151,18 @@ func init() {
 // Note:
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
-//
-//nolint:funlen,gocyclo
 func (r *NodeNetworkConfigurationPolicyReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.Start(ctx, "NodeNetworkConfigurationPolicy.Reconcile",
+		attribute.String("policy", request.Name),
+		attribute.String("node", nodeName),
+	)
+	result, err := r.reconcile(ctx, request)
+	tracing.End(span, err)
+	return result, err
+}
+
+//nolint:funlen,gocyclo
+func (r *NodeNetworkConfigurationPolicyReconciler) reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("nodenetworkconfigurationpolicy", request.NamespacedName)
 
 	// Fetch the NodeNetworkConfigurationPolicy instance
@@ -182,10 +206,9 @@ func (r *NodeNetworkConfigurationPolicyReconciler) Reconcile(ctx context.Context
 		log.Error(err, "Error initializing enactment")
 		return ctrl.Result{}, err
 	}
-	previousConditions := &enactmentInstance.Status.Conditions
 	enactmentConditions := enactmentconditions.New(r.APIClient, nmstateapi.EnactmentKey(nodeName, instance.Name))
 
-	err = r.fillInEnactmentStatus(ctx, instance, enactmentInstance, enactmentConditions)
+	desiredState, err := r.fillInEnactmentStatus(ctx, instance, enactmentInstance, enactmentConditions)
 	if err != nil {
 		log.Error(err, "failed filling in the NNCE status")
 		if apierrors.IsNotFound(err) {
@@ -216,94 +239,141 @@ func (r *NodeNetworkConfigurationPolicyReconciler) Reconcile(ctx context.Context
 		return ctrl.Result{}, err
 	}
 
+	retries := r.retryPolicy(instance)
+
 	// Skip apply if retries are already exhausted for this generation.
 	// This prevents unnecessary network disruption when a spurious reconcile
 	// (e.g., from informer re-list after reconnection) re-triggers processing
 	// of an already-failed policy.
-	if enactmentInstance.Status.RetryCount[generationKey] >= r.RetriesUntilFail {
+	if enactmentInstance.Status.RetryCount[generationKey] >= retries.maxRetries {
 		log.Info("Retry count already exhausted, skipping apply",
 			"retryCount", enactmentInstance.Status.RetryCount[generationKey],
-			"maxRetries", r.RetriesUntilFail,
+			"maxRetries", retries.maxRetries,
 			"generation", generationKey)
 		return ctrl.Result{}, nil
 	}
 
-	if r.shouldIncrementUnavailableNodeCount(previousConditions) {
-		err = r.incrementUnavailableNodeCount(ctx, instance, generationKey)
-		if err != nil {
-			if apierrors.IsConflict(err) || errors.Is(err, node.MaxUnavailableLimitReachedError{}) {
-				enactmentConditions.NotifyPending(ctx)
-				log.Info(err.Error())
-				shouldAbortEnactment, err := r.shouldAbortReconcile(ctx, instance)
-				if err != nil {
-					return ctrl.Result{}, err
-				}
-				if shouldAbortEnactment {
-					if r.Recorder != nil {
-						r.Recorder.Event(
-							instance,
-							corev1.EventTypeWarning,
-							ReconcileFailed,
-							fmt.Errorf("reconciliation of enactment %q has aborted", enactmentInstance.Name).Error())
-					}
-					enactmentConditions.NotifyAborted(ctx, fmt.Errorf("reconciliation of enactment %q has aborted", enactmentInstance.Name))
-					return ctrl.Result{}, nil
+	// The node keeps its slot while retrying, acquiring it again renews it
+	slotCtx, slotSpan := tracing.Start(ctx, "AcquireUnavailableNodeSlot")
+	slotLease, err := r.acquireSlot(slotCtx, instance, retries)
+	tracing.End(slotSpan, err)
+	if err != nil {
+		if errors.Is(err, node.MaxUnavailableLimitReachedError{}) {
+			r.slotWaits.pending(instance.Name, time.Now())
+			enactmentConditions.NotifyPending(ctx)
+			log.Info(err.Error())
+			shouldAbortEnactment, err := r.shouldAbortReconcile(ctx, instance)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if shouldAbortEnactment {
+				if r.Recorder != nil {
+					r.Recorder.Event(
+						instance,
+						corev1.EventTypeWarning,
+						ReconcileFailed,
+						fmt.Errorf("reconciliation of enactment %q has aborted", enactmentInstance.Name).Error())
 				}
-				return ctrl.Result{Requeue: true}, nil
+				r.slotWaits.done(instance.Name)
+				enactmentConditions.NotifyAborted(ctx, fmt.Errorf("reconciliation of enactment %q has aborted", enactmentInstance.Name))
+				monitoring.PolicyAborts.WithLabelValues(nodeName, instance.Name).Inc()
+				return ctrl.Result{}, nil
 			}
-			return ctrl.Result{}, err
+			return ctrl.Result{Requeue: true}, nil
 		}
+		return ctrl.Result{}, err
 	}
+	r.slotWaits.done(instance.Name)
 
 	enactmentConditions.NotifyProgressing(ctx)
 	if policyconditions.IsUnknown(&instance.Status.Conditions) {
 		policyconditions.Update(ctx, r.Client, r.APIClient, request.NamespacedName)
 	}
 
-	nmstateOutput, err := nmstate.ApplyDesiredState(ctx, r.APIClient, enactmentInstance.Status.DesiredState)
+	applyOptions := nmstate.ApplyOptions{
+		Verbose: instance.Annotations[nmstateapi.NodeNetworkConfigurationPolicyVerboseAnnotation] == "true",
+	}
+	if r.Transcripts.Size > 0 {
+		applyOptions.Transcript = &nmstate.Transcript{}
+		applyOptions.Transcript.Logf("enactment %s attempt %d/%d for policy generation %s",
+			enactmentInstance.Name, enactmentInstance.Status.RetryCount[generationKey]+1, retries.maxRetries, generationKey)
+	}
+	attemptStart := time.Now()
+	stopRenewingSlot := r.renewSlotWhileApplying(ctx, slotLease, retries)
+	nmstateOutput, err := nmstate.ApplyDesiredState(ctx, r.APIClient, desiredState, applyOptions)
+	if slotErr := stopRenewingSlot(); slotErr != nil {
+		r.markSlotLost(instance, slotLease, applyOptions.Transcript)
+	}
+	r.recordTranscript(ctx, enactmentInstance, attemptStart, applyOptions.Transcript, err)
 	if err != nil {
 		errmsg := fmt.Errorf("error reconciling NodeNetworkConfigurationPolicy on node %s at desired state apply: %q,\n %v",
 			nodeName, nmstateOutput, err)
 		log.Error(errmsg, fmt.Sprintf("Rolling back network configuration, manual intervention needed: %s", nmstateOutput))
+		if nmstate.IsRollback(err) {
+			monitoring.PolicyRollbacks.WithLabelValues(nodeName, instance.Name).Inc()
+		}
 		err := r.incrementNNCERetryCount(ctx, instance, enactmentInstance, generationKey)
 		if err != nil {
 			log.Info("Error incrementing NNCERetry count")
 			return ctrl.Result{}, err
 		}
 
-		if enactmentInstance.Status.RetryCount[generationKey] >= r.RetriesUntilFail {
+		if enactmentInstance.Status.RetryCount[generationKey] >= retries.maxRetries {
 			enactmentConditions.NotifyFailedToConfigure(ctx, errmsg)
+			// The failed enactment keeps the node unavailable from now on
+			r.failSlot(ctx, instance, retries)
 			if r.Recorder != nil {
 				r.Recorder.Event(instance,
 					corev1.EventTypeWarning,
 					ReconcileFailed,
 					fmt.Errorf(
 						"reconciliation of enactment %q has failed after %d retries",
-						enactmentInstance.Name, r.RetriesUntilFail).Error())
+						enactmentInstance.Name, retries.maxRetries).Error())
 			}
 			return ctrl.Result{}, nil
 		}
+		monitoring.PolicyRetries.WithLabelValues(nodeName, instance.Name).Inc()
 		enactmentConditions.NotifyRetrying(
 			ctx,
 			fmt.Errorf("failed to reconcile NodeNetworkConfigurationPolicy on node %s. Retrying %d/%d",
 				nodeName,
 				enactmentInstance.Status.RetryCount[generationKey]+1,
-				r.RetriesUntilFail),
+				retries.maxRetries),
 		)
-		return ctrl.Result{Requeue: true}, nil
+		return ctrl.Result{RequeueAfter: retries.backoff(enactmentInstance.Status.RetryCount[generationKey])}, nil
 	}
 	log.Info("nmstate", "output", nmstateOutput)
 
 	enactmentConditions.NotifySuccess(ctx)
-	if err := r.decrementUnavailableNodeCount(ctx, instance, generationKey); err != nil {
-		r.Log.Info("Failed to update NNCP status, will retry", "error", err, "requeueAfter", "10s")
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
-	}
-	r.forceNNSRefresh(ctx, nodeName)
+	r.releaseSlot(ctx, instance.Name)
+	r.forceNNSRefresh(ctx, nodeName, instance.Name)
 
 	return ctrl.Result{}, nil
 }
 
+// recordTranscript stores the attempt transcript, it is informative so
+// failing to store it does not fail the reconcile
+func (r *NodeNetworkConfigurationPolicyReconciler) recordTranscript(
+	ctx context.Context,
+	enactment *nmstatev1beta1.NodeNetworkConfigurationEnactment,
+	attemptStart time.Time,
+	transcript *nmstate.Transcript,
+	applyErr error,
+) {
+	if transcript == nil {
+		return
+	}
+	if applyErr != nil {
+		transcript.Logf("attempt failed: %v", applyErr)
+	} else {
+		transcript.Logf("attempt succeeded")
+	}
+	err := nmstate.RecordEnactmentTranscript(ctx, r.Client, enactment, r.Namespace, r.Transcripts, attemptStart, transcript)
+	if err != nil {
+		r.Log.Error(err, "failed recording enactment transcript", "enactment", enactment.Name)
+	}
+}
+
 func (r *NodeNetworkConfigurationPolicyReconciler) incrementNNCERetryCount(
 	ctx context.Context,
 	instance *nmstatev1.NodeNetworkConfigurationPolicy,
@@ -441,19 +511,21 @@ func (r *NodeNetworkConfigurationPolicyReconciler) fillInEnactmentStatus(
 	ctx context.Context,
 	policy *nmstatev1.NodeNetworkConfigurationPolicy,
 	enactmentInstance *nmstatev1beta1.NodeNetworkConfigurationEnactment,
-	enactmentConditions enactmentconditions.EnactmentConditions) error {
+	enactmentConditions enactmentconditions.EnactmentConditions) (nmstateapi.State, error) {
 	log := r.Log.WithValues("nodenetworkconfigurationpolicy.fillInEnactmentStatus", enactmentInstance.Name)
 	currentState, err := nmstatectlShowFn()
 	if err != nil {
-		return err
+		return nmstateapi.State{}, err
 	}
 
+	_, generateSpan := tracing.Start(ctx, "GenerateState")
 	capturedStates, generatedDesiredState, err := nmpolicy.GenerateState(
 		policy.Spec.DesiredState,
 		policy.Spec,
 		nmstateapi.NewState(currentState),
 		enactmentInstance.Status.CapturedStates,
 	)
+	tracing.End(generateSpan, err)
 	if err != nil {
 		err2 := enactmentstatus.Update(
 			ctx,
@@ -464,19 +536,23 @@ func (r *NodeNetworkConfigurationPolicyReconciler) fillInEnactmentStatus(
 			},
 		)
 		if err2 != nil {
-			return err2
+			return nmstateapi.State{}, err2
 		}
 		enactmentConditions.NotifyGenerateFailure(ctx, err)
-		return err
+		return nmstateapi.State{}, err
 	}
 
+	_, vlanFilteringSpan := tracing.Start(ctx, "ApplyDefaultVlanFiltering")
 	desiredStateWithDefaults, err := bridge.ApplyDefaultVlanFiltering(generatedDesiredState)
+	tracing.End(vlanFilteringSpan, err)
 	if err != nil {
-		return err
+		return nmstateapi.State{}, err
 	}
 
 	features := []string{}
+	_, statisticSpan := tracing.Start(ctx, "Statistic")
 	stats, err := nmstatectl.Statistic(desiredStateWithDefaults)
+	tracing.End(statisticSpan, err)
 	if err != nil {
 		log.Error(err, "failed calculating nmstate statistics")
 	} else {
@@ -485,7 +561,9 @@ func (r *NodeNetworkConfigurationPolicyReconciler) fillInEnactmentStatus(
 		}
 	}
 
-	return enactmentstatus.Update(
+	// The status desired state is redacted so the one to apply is returned
+	// from here.
+	err = enactmentstatus.Update(
 		ctx,
 		r.APIClient,
 		nmstateapi.EnactmentKey(nodeName, policy.Name),
@@ -496,6 +574,10 @@ func (r *NodeNetworkConfigurationPolicyReconciler) fillInEnactmentStatus(
 			status.Features = features
 		},
 	)
+	if err != nil {
+		return nmstateapi.State{}, err
+	}
+	return desiredStateWithDefaults, nil
 }
 
 // resetPolicyGeneration updates the enactment's PolicyGeneration and clears
@@ -544,6 +626,8 @@ func (r *NodeNetworkConfigurationPolicyReconciler) waitEnactmentCreated(ctx cont
 }
 
 func (r *NodeNetworkConfigurationPolicyReconciler) deleteEnactmentForPolicy(ctx context.Context, policyName string) error {
+	r.slotWaits.forget(policyName)
+	r.releaseSlot(ctx, policyName)
 	enactmentKey := nmstateapi.EnactmentKey(nodeName, policyName)
 	log := r.Log.WithName("deleteEnactmentForPolicy").WithValues(
 		"policy", policyName,
@@ -565,85 +649,110 @@ func (r *NodeNetworkConfigurationPolicyReconciler) deleteEnactmentForPolicy(ctx
 	return nil
 }
 
-func (r *NodeNetworkConfigurationPolicyReconciler) shouldIncrementUnavailableNodeCount(
-	conditions *nmstateapi.ConditionList) bool {
-	log := r.Log.WithName("shouldIncrementUnavailableNodeCount").WithValues(
-		"conditions", conditions)
-	shouldIncrement := conditions != nil && !enactmentstatus.IsRetrying(conditions)
-	log.Info("shouldIncrementUnavailableNodeCount", "shouldIncrement", shouldIncrement)
-	return shouldIncrement
+func (r *NodeNetworkConfigurationPolicyReconciler) slots(retries retryPolicy) *node.Slots {
+	// The slot has to outlive the backoff between retries. The free slots are
+	// scanned from the cache, the held one is read from the API server since
+	// the cache may be stale after applying.
+	return &node.Slots{
+		Client:        r.APIClient,
+		Reader:        r.Client,
+		Namespace:     r.Namespace,
+		NodeName:      nodeName,
+		LeaseDuration: max(node.DefaultSlotLeaseDuration, 2*retries.maxBackoff),
+	}
 }
 
-func (r *NodeNetworkConfigurationPolicyReconciler) incrementUnavailableNodeCount(
+func (r *NodeNetworkConfigurationPolicyReconciler) acquireSlot(
 	ctx context.Context,
 	policy *nmstatev1.NodeNetworkConfigurationPolicy,
-	generationKey string) error {
-	policyKey := types.NamespacedName{Name: policy.GetName(), Namespace: policy.GetNamespace()}
-	return retry.OnError(retry.DefaultRetry, func(error) bool { return true }, func() error {
-		err := r.Get(ctx, policyKey, policy)
-		if err != nil {
-			return err
-		}
-		maxUnavailable, err := node.MaxUnavailableNodeCount(ctx, r.APIClient, policy)
-		if err != nil {
-			r.Log.Info(
-				fmt.Sprintf("failed calculating limit of max unavailable nodes, defaulting to %d, err: %s", maxUnavailable, err.Error()),
-			)
-		}
+	retries retryPolicy,
+) (string, error) {
+	maxUnavailable, err := node.MaxUnavailableNodeCount(ctx, r.APIClient, policy)
+	if err != nil {
+		r.Log.Info(
+			fmt.Sprintf("failed calculating limit of max unavailable nodes, defaulting to %d, err: %s", maxUnavailable, err.Error()),
+		)
+	}
+	return r.slots(retries).Acquire(ctx, policy, maxUnavailable)
+}
 
-		if policy.Status.UnavailableNodeCountMap == nil {
-			policy.Status.UnavailableNodeCountMap = map[string]int{}
-		}
-		if policy.Status.UnavailableNodeCountMap[generationKey] >= maxUnavailable {
-			return node.MaxUnavailableLimitReachedError{}
+// renewSlotWhileApplying keeps the slot while the desired state is applied,
+// the returned function stops renewing it and returns SlotLostError if the
+// slot was taken by another node meanwhile.
+func (r *NodeNetworkConfigurationPolicyReconciler) renewSlotWhileApplying(
+	ctx context.Context,
+	slotLease string,
+	retries retryPolicy,
+) func() error {
+	slots := r.slots(retries)
+	renewCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(slots.LeaseDuration / 4)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-renewCtx.Done():
+				return
+			case <-ticker.C:
+				err := slots.Renew(renewCtx, slotLease)
+				if err == nil || renewCtx.Err() != nil {
+					continue
+				}
+				if errors.Is(err, node.SlotLostError{}) {
+					// There is nothing to renew anymore
+					done <- err
+					return
+				}
+				r.Log.Error(err, "failed renewing maxUnavailable slot", "lease", slotLease)
+			}
 		}
-		policy.Status.UnavailableNodeCountMap[generationKey] += 1
-		return r.Client.Status().Update(ctx, policy)
-	})
+	}()
+	return func() error {
+		cancel()
+		return <-done
+	}
 }
 
-func (r *NodeNetworkConfigurationPolicyReconciler) decrementUnavailableNodeCount(
-	ctx context.Context,
+// markSlotLost reports that the node kept configuring the policy after losing
+// its slot, nmstate apply cannot be interrupted so more nodes than
+// maxUnavailable may have been configured at the same time.
+func (r *NodeNetworkConfigurationPolicyReconciler) markSlotLost(
 	policy *nmstatev1.NodeNetworkConfigurationPolicy,
-	generationKey string) error {
-	policyKey := types.NamespacedName{Name: policy.GetName(), Namespace: policy.GetNamespace()}
-	err := tryDecrementingUnavailableNodeCount(ctx, r.Client, r.Client, policyKey, generationKey)
-	if err != nil {
-		r.Log.Error(err, "error decrementing unavailableNodeCount with cached client, trying again with non-cached client.")
-		err = tryDecrementingUnavailableNodeCount(ctx, r.Client, r.APIClient, policyKey, generationKey)
-		if err != nil {
-			r.Log.Error(err, "error decrementing unavailableNodeCount with non-cached client")
-			return err
-		}
+	slotLease string,
+	transcript *nmstate.Transcript,
+) {
+	message := fmt.Sprintf("maxUnavailable slot %s was taken by another node while node %s was configuring the policy", slotLease, nodeName)
+	r.Log.Error(node.SlotLostError{}, message, "policy", policy.Name)
+	transcript.Logf("%s", message)
+	if r.Recorder != nil {
+		r.Recorder.Event(policy, corev1.EventTypeWarning, SlotLost, message)
 	}
-	return nil
 }
 
-func tryDecrementingUnavailableNodeCount(
+// failSlot keeps the node slot until the policy changes, if it fails the
+// slot is freed once its lease expires
+func (r *NodeNetworkConfigurationPolicyReconciler) failSlot(
 	ctx context.Context,
-	statusWriterClient client.StatusClient,
-	readerClient client.Reader,
-	policyKey types.NamespacedName,
-	generationKey string) error {
-	instance := &nmstatev1.NodeNetworkConfigurationPolicy{}
-	err := retry.OnError(retry.DefaultRetry, func(error) bool { return true }, func() error {
-		err := readerClient.Get(ctx, policyKey, instance)
-		if err != nil {
-			return err
-		}
-		if instance.Status.UnavailableNodeCountMap == nil {
-			instance.Status.UnavailableNodeCountMap = map[string]int{}
-		}
-		if instance.Status.UnavailableNodeCountMap[generationKey] <= 0 {
-			return nil
-		}
-		instance.Status.UnavailableNodeCountMap[generationKey] -= 1
-		return statusWriterClient.Status().Update(ctx, instance)
-	})
-	return err
+	policy *nmstatev1.NodeNetworkConfigurationPolicy,
+	retries retryPolicy,
+) {
+	if err := r.slots(retries).Fail(ctx, policy); err != nil {
+		r.Log.Error(err, "failed keeping maxUnavailable slot of the failed node, it will expire", "policy", policy.Name)
+	}
 }
 
-func (r *NodeNetworkConfigurationPolicyReconciler) forceNNSRefresh(ctx context.Context, name string) {
+// releaseSlot frees the node slot, if it fails the slot is freed once its
+// lease expires
+func (r *NodeNetworkConfigurationPolicyReconciler) releaseSlot(ctx context.Context, policyName string) {
+	// The lease duration does not matter to release the slot
+	if err := r.slots(retryPolicy{}).Release(ctx, policyName); err != nil {
+		r.Log.Error(err, "failed releasing maxUnavailable slot, it will expire", "policy", policyName)
+	}
+}
+
+func (r *NodeNetworkConfigurationPolicyReconciler) forceNNSRefresh(ctx context.Context, name, policyName string) {
 	log := r.Log.WithName("forceNNSRefresh").WithValues("node", name)
 	log.Info("forcing NodeNetworkState refresh after NNCP applied")
 	nns, err := r.readNNS(ctx, name)
@@ -656,6 +765,10 @@ func (r *NodeNetworkConfigurationPolicyReconciler) forceNNSRefresh(ctx context.C
 		nns.Labels = map[string]string{}
 	}
 	nns.Labels[forceRefreshLabel] = fmt.Sprintf("%d", time.Now().UnixNano())
+	if nns.Annotations == nil {
+		nns.Annotations = map[string]string{}
+	}
+	nns.Annotations[forceRefreshPolicyAnnotation] = policyName
 
 	err = r.Update(ctx, nns)
 	if err != nil {
@@ -23,6 +23,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -87,13 +88,14 @@ var _ = Describe("NodeNetworkConfigurationPolicy controller predicates", func()
 			}),
 	)
 
-	type incrementUnavailableNodeCountCase struct {
-		currentUnavailableNodeCount int
+	type acquireSlotCase struct {
+		slotHeldByOtherNode         bool
 		expectedReconcileResult     ctrl.Result
+		expectedSlotHolder          string
 		previousEnactmentConditions func(*shared.ConditionList, string)
 	}
-	DescribeTable("when claimNodeRunningUpdate is called and",
-		func(c incrementUnavailableNodeCountCase) {
+	DescribeTable("when the maxUnavailable slot is acquired and",
+		func(c acquireSlotCase) {
 			nmstatectlShowFn = func() (string, error) { return "", nil }
 			reconciler := NodeNetworkConfigurationPolicyReconciler{
 				RetriesUntilFail:   5,
@@ -126,9 +128,6 @@ var _ = Describe("NodeNetworkConfigurationPolicy controller predicates", func()
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test",
 				},
-				Status: shared.NodeNetworkConfigurationPolicyStatus{
-					UnavailableNodeCountMap: map[string]int{},
-				},
 			}
 			nnce := nmstatev1beta1.NodeNetworkConfigurationEnactment{
 				ObjectMeta: metav1.ObjectMeta{
@@ -141,6 +140,22 @@ var _ = Describe("NodeNetworkConfigurationPolicy controller predicates", func()
 			c.previousEnactmentConditions(&nnce.Status.Conditions, "")
 
 			objs := []runtime.Object{&nncp, &nnce, &nns, &node}
+			if c.slotHeldByOtherNode {
+				otherNode := "other-node"
+				leaseDurationSeconds := int32(120)
+				objs = append(objs, &coordinationv1.Lease{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-slot-0",
+						Namespace: "nmstate",
+						Labels:    map[string]string{shared.EnactmentPolicyLabel: nncp.Name},
+					},
+					Spec: coordinationv1.LeaseSpec{
+						HolderIdentity:       &otherNode,
+						LeaseDurationSeconds: &leaseDurationSeconds,
+						RenewTime:            &metav1.MicroTime{Time: time.Now()},
+					},
+				})
+			}
 
 			// Create a fake client to mock API calls.
 			clb := fake.ClientBuilder{}
@@ -154,6 +169,7 @@ var _ = Describe("NodeNetworkConfigurationPolicy controller predicates", func()
 			reconciler.Client = cl
 			reconciler.APIClient = cl
 			reconciler.Log = ctrl.Log.WithName("controllers").WithName("NodeNetworkConfigurationPolicy")
+			reconciler.Namespace = "nmstate"
 
 			res, err := reconciler.Reconcile(context.TODO(), ctrl.Request{
 				NamespacedName: types.NamespacedName{Name: nncp.Name},
@@ -161,43 +177,53 @@ var _ = Describe("NodeNetworkConfigurationPolicy controller predicates", func()
 
 			Expect(err).To(BeNil())
 			Expect(res).To(Equal(c.expectedReconcileResult))
+
+			lease := &coordinationv1.Lease{}
+			Expect(cl.Get(context.TODO(), types.NamespacedName{Namespace: "nmstate", Name: "test-slot-0"}, lease)).To(Succeed())
+			Expect(*lease.Spec.HolderIdentity).To(Equal(c.expectedSlotHolder))
 		},
 
-		Entry("No node applying policy with empty enactment, should succeed incrementing UnavailableNodeCount",
-			incrementUnavailableNodeCountCase{
-				currentUnavailableNodeCount: 0,
+		Entry("No node applying policy with empty enactment, should take the maxUnavailable slot",
+			acquireSlotCase{
+				slotHeldByOtherNode:         false,
 				previousEnactmentConditions: func(*shared.ConditionList, string) {},
-				expectedReconcileResult:     ctrl.Result{Requeue: true},
+				expectedReconcileResult:     ctrl.Result{RequeueAfter: time.Second},
+				expectedSlotHolder:          nodeName,
 			}),
-		Entry("No node applying policy with progressing enactment, should succeed incrementing UnavailableNodeCount",
-			incrementUnavailableNodeCountCase{
-				currentUnavailableNodeCount: 0,
+		Entry("No node applying policy with Progressing enactment, should take the maxUnavailable slot",
+			acquireSlotCase{
+				slotHeldByOtherNode:         false,
 				previousEnactmentConditions: conditions.SetProgressing,
-				expectedReconcileResult:     ctrl.Result{Requeue: true},
+				expectedReconcileResult:     ctrl.Result{RequeueAfter: time.Second},
+				expectedSlotHolder:          nodeName,
 			}),
-		Entry("No node applying policy with Pending enactment, should succeed incrementing UnavailableNodeCount",
-			incrementUnavailableNodeCountCase{
-				currentUnavailableNodeCount: 0,
+		Entry("No node applying policy with Pending enactment, should take the maxUnavailable slot",
+			acquireSlotCase{
+				slotHeldByOtherNode:         false,
 				previousEnactmentConditions: conditions.SetPending,
-				expectedReconcileResult:     ctrl.Result{Requeue: true},
+				expectedReconcileResult:     ctrl.Result{RequeueAfter: time.Second},
+				expectedSlotHolder:          nodeName,
 			}),
-		Entry("One node applying policy with empty enactment, should conflict incrementing UnavailableNodeCount",
-			incrementUnavailableNodeCountCase{
-				currentUnavailableNodeCount: 1,
+		Entry("One node applying policy with empty enactment, should wait for the maxUnavailable slot",
+			acquireSlotCase{
+				slotHeldByOtherNode:         true,
 				previousEnactmentConditions: func(*shared.ConditionList, string) {},
 				expectedReconcileResult:     ctrl.Result{Requeue: true},
+				expectedSlotHolder:          "other-node",
 			}),
-		Entry("One node applying policy with Progressing enactment, should conflict incrementing UnavailableNodeCount",
-			incrementUnavailableNodeCountCase{
-				currentUnavailableNodeCount: 1,
+		Entry("One node applying policy with Progressing enactment, should wait for the maxUnavailable slot",
+			acquireSlotCase{
+				slotHeldByOtherNode:         true,
 				previousEnactmentConditions: conditions.SetProgressing,
 				expectedReconcileResult:     ctrl.Result{Requeue: true},
+				expectedSlotHolder:          "other-node",
 			}),
-		Entry("One node applying policy with Pending enactment, should conflict incrementing UnavailableNodeCount",
-			incrementUnavailableNodeCountCase{
-				currentUnavailableNodeCount: 1,
+		Entry("One node applying policy with Pending enactment, should wait for the maxUnavailable slot",
+			acquireSlotCase{
+				slotHeldByOtherNode:         true,
 				previousEnactmentConditions: conditions.SetPending,
 				expectedReconcileResult:     ctrl.Result{Requeue: true},
+				expectedSlotHolder:          "other-node",
 			}),
 	)
 
@@ -302,130 +328,6 @@ var _ = Describe("NodeNetworkConfigurationPolicy controller predicates", func()
 		})
 	})
 
-	Describe("decrementUnavailableNodeCount", func() {
-		var (
-			reconciler *NodeNetworkConfigurationPolicyReconciler
-			nncp       *nmstatev1.NodeNetworkConfigurationPolicy
-			s          *runtime.Scheme
-		)
-
-		BeforeEach(func() {
-			reconciler = &NodeNetworkConfigurationPolicyReconciler{
-				RetriesUntilFail:   5,
-				MaximumTimeBackoff: 30 * time.Second,
-				InitialBackoff:     1 * time.Second,
-			}
-			s = scheme.Scheme
-			s.AddKnownTypes(nmstatev1.GroupVersion,
-				&nmstatev1.NodeNetworkConfigurationPolicy{},
-				&nmstatev1.NodeNetworkConfigurationPolicyList{},
-			)
-
-			nncp = &nmstatev1.NodeNetworkConfigurationPolicy{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "test-policy",
-				},
-				Status: shared.NodeNetworkConfigurationPolicyStatus{
-					UnavailableNodeCountMap: map[string]int{
-						"gen-1": 2,
-					},
-				},
-			}
-
-			reconciler.Log = ctrl.Log.WithName("test")
-		})
-
-		Context("when status update succeeds", func() {
-			It("should decrement unavailable node count and return nil", func() {
-				clb := fake.ClientBuilder{}
-				clb.WithScheme(s)
-				clb.WithRuntimeObjects(nncp)
-				clb.WithStatusSubresource(nncp)
-				cl := clb.Build()
-
-				reconciler.Client = cl
-				reconciler.APIClient = cl
-
-				err := reconciler.decrementUnavailableNodeCount(context.TODO(), nncp, "gen-1")
-
-				Expect(err).To(BeNil())
-
-				// Verify the count was decremented
-				updatedNNCP := &nmstatev1.NodeNetworkConfigurationPolicy{}
-				err = cl.Get(context.TODO(), types.NamespacedName{Name: "test-policy"}, updatedNNCP)
-				Expect(err).To(BeNil())
-				Expect(updatedNNCP.Status.UnavailableNodeCountMap["gen-1"]).To(Equal(1))
-			})
-		})
-
-		Context("when status update fails with both cached and non-cached clients", func() {
-			It("should return error", func() {
-				// Create a client that will fail status updates
-				clb := fake.ClientBuilder{}
-				clb.WithScheme(s)
-				clb.WithRuntimeObjects(nncp)
-				// Note: NOT adding WithStatusSubresource - this causes status updates to fail
-				cl := clb.Build()
-
-				reconciler.Client = cl
-				reconciler.APIClient = cl
-
-				err := reconciler.decrementUnavailableNodeCount(context.TODO(), nncp, "gen-1")
-
-				Expect(err).ToNot(BeNil())
-				Expect(err.Error()).To(ContainSubstring("not found"))
-			})
-		})
-
-		Context("when unavailable node count is already zero", func() {
-			It("should return nil without error", func() {
-				nncpZeroCount := &nmstatev1.NodeNetworkConfigurationPolicy{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "test-policy-zero",
-					},
-					Status: shared.NodeNetworkConfigurationPolicyStatus{
-						UnavailableNodeCountMap: map[string]int{
-							"gen-1": 0,
-						},
-					},
-				}
-
-				clb := fake.ClientBuilder{}
-				clb.WithScheme(s)
-				clb.WithRuntimeObjects(nncpZeroCount)
-				clb.WithStatusSubresource(nncpZeroCount)
-				cl := clb.Build()
-
-				reconciler.Client = cl
-				reconciler.APIClient = cl
-
-				err := reconciler.decrementUnavailableNodeCount(context.TODO(), nncpZeroCount, "gen-1")
-
-				// Should not return error - this is expected when node already processed
-				Expect(err).To(BeNil())
-			})
-		})
-
-		Context("when generation key doesn't exist", func() {
-			It("should return nil without error", func() {
-				clb := fake.ClientBuilder{}
-				clb.WithScheme(s)
-				clb.WithRuntimeObjects(nncp)
-				clb.WithStatusSubresource(nncp)
-				cl := clb.Build()
-
-				reconciler.Client = cl
-				reconciler.APIClient = cl
-
-				// Try to decrement a generation key that doesn't exist
-				err := reconciler.decrementUnavailableNodeCount(context.TODO(), nncp, "non-existent-gen")
-
-				// Should not return error - this is expected when node already processed
-				Expect(err).To(BeNil())
-			})
-		})
-	})
-
 	Describe("fillInEnactmentStatus", func() {
 		var (
 			reconciler *NodeNetworkConfigurationPolicyReconciler
@@ -484,7 +386,7 @@ var _ = Describe("NodeNetworkConfigurationPolicy controller predicates", func()
 
 				enactmentConditions := conditions.New(cl, shared.EnactmentKey(nodeName, nncp.Name))
 
-				err := reconciler.fillInEnactmentStatus(context.TODO(), &nncp, &nnce, enactmentConditions)
+				_, err := reconciler.fillInEnactmentStatus(context.TODO(), &nncp, &nnce, enactmentConditions)
 				Expect(err).ToNot(HaveOccurred())
 
 				updatedNNCE := &nmstatev1beta1.NodeNetworkConfigurationEnactment{}
@@ -529,7 +431,7 @@ var _ = Describe("NodeNetworkConfigurationPolicy controller predicates", func()
 
 				enactmentConditions := conditions.New(cl, shared.EnactmentKey(nodeName, nncp.Name))
 
-				err := reconciler.fillInEnactmentStatus(context.TODO(), &nncp, &nnce, enactmentConditions)
+				_, err := reconciler.fillInEnactmentStatus(context.TODO(), &nncp, &nnce, enactmentConditions)
 				Expect(err).ToNot(HaveOccurred())
 
 				updatedNNCE := &nmstatev1beta1.NodeNetworkConfigurationEnactment{}
@@ -0,0 +1,66 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	nmstatev1 "github.com/nmstate/kubernetes-nmstate/api/v1"
+)
+
+// retryPolicy is the retries configuration of a policy, the handler one with
+// the policy spec.retryPolicy overrides applied.
+type retryPolicy struct {
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+func (r *NodeNetworkConfigurationPolicyReconciler) retryPolicy(policy *nmstatev1.NodeNetworkConfigurationPolicy) retryPolicy {
+	retries := retryPolicy{
+		maxRetries:     r.RetriesUntilFail,
+		initialBackoff: r.InitialBackoff,
+		maxBackoff:     r.MaximumTimeBackoff,
+	}
+	overrides := policy.Spec.RetryPolicy
+	if overrides == nil {
+		return retries
+	}
+	if overrides.MaxRetries != nil {
+		retries.maxRetries = int(*overrides.MaxRetries)
+	}
+	if overrides.InitialBackoff != nil {
+		retries.initialBackoff = overrides.InitialBackoff.Duration
+	}
+	if overrides.MaxBackoff != nil {
+		retries.maxBackoff = overrides.MaxBackoff.Duration
+	}
+	// A policy overriding only one of the backoffs can cross the handler one
+	retries.initialBackoff = min(retries.initialBackoff, retries.maxBackoff)
+	return retries
+}
+
+// backoff returns the wait before retrying after the given failed attempts,
+// it doubles from the initial backoff up to the max backoff.
+func (p retryPolicy) backoff(failedAttempts int) time.Duration {
+	backoff := p.initialBackoff
+	for i := 1; i < failedAttempts && backoff < p.maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, p.maxBackoff)
+}
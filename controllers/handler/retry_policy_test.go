@@ -0,0 +1,74 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	nmstateapi "github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstatev1 "github.com/nmstate/kubernetes-nmstate/api/v1"
+)
+
+var _ = Describe("retryPolicy", func() {
+	var reconciler = NodeNetworkConfigurationPolicyReconciler{
+		RetriesUntilFail:   5,
+		InitialBackoff:     time.Second,
+		MaximumTimeBackoff: 30 * time.Second,
+	}
+	It("should use the handler configuration without policy overrides", func() {
+		retries := reconciler.retryPolicy(&nmstatev1.NodeNetworkConfigurationPolicy{})
+		Expect(retries).To(Equal(retryPolicy{maxRetries: 5, initialBackoff: time.Second, maxBackoff: 30 * time.Second}))
+	})
+	It("should apply the policy overrides", func() {
+		policy := &nmstatev1.NodeNetworkConfigurationPolicy{
+			Spec: nmstateapi.NodeNetworkConfigurationPolicySpec{
+				RetryPolicy: &nmstateapi.RetryPolicy{
+					MaxRetries:     ptr.To[int32](2),
+					InitialBackoff: &metav1.Duration{Duration: 10 * time.Second},
+					MaxBackoff:     &metav1.Duration{Duration: time.Minute},
+				},
+			},
+		}
+		Expect(reconciler.retryPolicy(policy)).To(Equal(retryPolicy{maxRetries: 2, initialBackoff: 10 * time.Second, maxBackoff: time.Minute}))
+	})
+	It("should cap the initial backoff with a lower policy max backoff", func() {
+		policy := &nmstatev1.NodeNetworkConfigurationPolicy{
+			Spec: nmstateapi.NodeNetworkConfigurationPolicySpec{
+				RetryPolicy: &nmstateapi.RetryPolicy{
+					InitialBackoff: &metav1.Duration{Duration: 45 * time.Second},
+				},
+			},
+		}
+		Expect(reconciler.retryPolicy(policy).initialBackoff).To(Equal(30 * time.Second))
+	})
+	DescribeTable("backoff", func(failedAttempts int, expectedBackoff time.Duration) {
+		retries := retryPolicy{initialBackoff: 2 * time.Second, maxBackoff: 20 * time.Second}
+		Expect(retries.backoff(failedAttempts)).To(Equal(expectedBackoff))
+	},
+		Entry("after the first failure", 1, 2*time.Second),
+		Entry("doubles after each failure", 3, 8*time.Second),
+		Entry("is capped at max backoff", 5, 20*time.Second),
+		Entry("stays at max backoff", 50, 20*time.Second),
+	)
+})
@@ -0,0 +1,68 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nmstate/kubernetes-nmstate/pkg/monitoring"
+)
+
+// slotWaits tracks since when the node waits for a maxUnavailable slot of
+// every policy to export how long the node is pending. The pending time is
+// refreshed at every requeue while waiting.
+type slotWaits struct {
+	lock  sync.Mutex
+	since map[string]time.Time
+}
+
+func (w *slotWaits) pending(policyName string, now time.Time) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.since == nil {
+		w.since = map[string]time.Time{}
+	}
+	start, waiting := w.since[policyName]
+	if !waiting {
+		start = now
+		w.since[policyName] = start
+	}
+	monitoring.PolicySlotPending.WithLabelValues(nodeName, policyName).Set(1)
+	monitoring.PolicySlotPendingSeconds.WithLabelValues(nodeName, policyName).Set(now.Sub(start).Seconds())
+}
+
+func (w *slotWaits) done(policyName string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if _, waiting := w.since[policyName]; !waiting {
+		return
+	}
+	delete(w.since, policyName)
+	monitoring.PolicySlotPending.WithLabelValues(nodeName, policyName).Set(0)
+	monitoring.PolicySlotPendingSeconds.WithLabelValues(nodeName, policyName).Set(0)
+}
+
+// forget removes the policy series once the node has no enactment for it
+func (w *slotWaits) forget(policyName string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	delete(w.since, policyName)
+	monitoring.PolicySlotPending.DeleteLabelValues(nodeName, policyName)
+	monitoring.PolicySlotPendingSeconds.DeleteLabelValues(nodeName, policyName)
+}
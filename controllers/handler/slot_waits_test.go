@@ -0,0 +1,62 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus"
+	pgo "github.com/prometheus/client_model/go"
+
+	"github.com/nmstate/kubernetes-nmstate/pkg/monitoring"
+)
+
+var _ = Describe("slotWaits", func() {
+	var (
+		waits      *slotWaits
+		now        = time.Date(2026, 3, 12, 3, 12, 0, 0, time.UTC)
+		gaugeValue = func(gauge *prometheus.GaugeVec) float64 {
+			metric := &pgo.Metric{}
+			ExpectWithOffset(1, gauge.WithLabelValues(nodeName, "policy1").Write(metric)).To(Succeed())
+			return metric.GetGauge().GetValue()
+		}
+	)
+	BeforeEach(func() {
+		waits = &slotWaits{}
+	})
+	AfterEach(func() {
+		waits.forget("policy1")
+	})
+	It("should report the time pending since the first requeue", func() {
+		waits.pending("policy1", now)
+		waits.pending("policy1", now.Add(30*time.Second))
+		Expect(gaugeValue(monitoring.PolicySlotPending)).To(Equal(1.0))
+		Expect(gaugeValue(monitoring.PolicySlotPendingSeconds)).To(Equal(30.0))
+	})
+	It("should reset the pending gauges once the slot is acquired", func() {
+		waits.pending("policy1", now)
+		waits.done("policy1")
+		Expect(gaugeValue(monitoring.PolicySlotPending)).To(Equal(0.0))
+		Expect(gaugeValue(monitoring.PolicySlotPendingSeconds)).To(Equal(0.0))
+		waits.pending("policy1", now.Add(time.Minute))
+		Expect(gaugeValue(monitoring.PolicySlotPendingSeconds)).To(Equal(0.0))
+	})
+})
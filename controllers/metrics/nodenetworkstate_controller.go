@@ -24,13 +24,19 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
 	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
 	"github.com/nmstate/kubernetes-nmstate/pkg/monitoring"
 	"github.com/nmstate/kubernetes-nmstate/pkg/state"
@@ -45,6 +51,15 @@ type NodeNetworkStateReconciler struct {
 	oldInterfaceTypes map[string]map[string]struct{} // node name -> set of interface types
 	// Track route keys per node to clean up stale metrics
 	oldRouteKeys map[string]map[state.RouteKey]struct{} // node name -> set of route keys
+	// InterfaceMetrics enables the per interface link health metrics, nil disables them
+	InterfaceMetrics *shared.InterfaceMetrics
+	// Track reported interfaces per node to clean up stale metrics
+	oldLinkHealthKeys map[string]map[linkHealthKey]struct{} // node name -> set of interfaces
+}
+
+type linkHealthKey struct {
+	name      string
+	ifaceType string
 }
 
 // Reconcile reads the state of the cluster for a NodeNetworkState object and calculates
@@ -87,9 +102,46 @@ func (r *NodeNetworkStateReconciler) Reconcile(ctx context.Context, request ctrl
 	// Update route metrics for this node
 	r.updateNodeRouteMetrics(nodeName, routeCounts)
 
+	updateNodeHealthMetrics(nodeName, nnsInstance.Status.Conditions)
+
+	if r.InterfaceMetrics != nil {
+		currentState, err := r.currentStateWithInterfaces(ctx, nnsInstance)
+		if err != nil {
+			log.Error(err, "Failed to retrieve the NodeNetworkInterfaces")
+			return ctrl.Result{}, err
+		}
+		linksHealth, err := state.InterfacesLinkHealth(currentState)
+		if err != nil {
+			log.Error(err, "Failed to calculate interfaces link health")
+			return ctrl.Result{}, err
+		}
+		r.updateNodeLinkHealthMetrics(nodeName, linksHealth)
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// currentStateWithInterfaces returns the NodeNetworkState current state with
+// the full interfaces state if they are reported at NodeNetworkInterfaces.
+func (r *NodeNetworkStateReconciler) currentStateWithInterfaces(
+	ctx context.Context,
+	nnsInstance *nmstatev1beta1.NodeNetworkState,
+) (shared.State, error) {
+	nnis := nmstatev1beta1.NodeNetworkInterfaceList{}
+	err := r.List(ctx, &nnis, client.MatchingLabels{shared.NodeNetworkInterfaceNodeLabel: nnsInstance.Name})
+	if err != nil {
+		return nnsInstance.Status.CurrentState, err
+	}
+	if len(nnis.Items) == 0 {
+		return nnsInstance.Status.CurrentState, nil
+	}
+	interfaces := []shared.State{}
+	for _, nni := range nnis.Items {
+		interfaces = append(interfaces, nni.Status.CurrentState)
+	}
+	return state.JoinInterfaces(nnsInstance.Status.CurrentState, interfaces)
+}
+
 func (r *NodeNetworkStateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.oldInterfaceTypes = make(map[string]map[string]struct{})
 	r.oldRouteKeys = make(map[string]map[state.RouteKey]struct{})
@@ -111,18 +163,34 @@ func (r *NodeNetworkStateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				return false
 			}
 
-			// Reconcile if the current state has changed
-			return oldNNS.Status.CurrentState.String() != newNNS.Status.CurrentState.String()
+			// Reconcile if the current state or the health has changed
+			return oldNNS.Status.CurrentState.String() != newNNS.Status.CurrentState.String() ||
+				healthChanged(oldNNS.Status.Conditions, newNNS.Status.Conditions)
 		},
 		GenericFunc: func(event.GenericEvent) bool {
 			return false
 		},
 	}
 
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&nmstatev1beta1.NodeNetworkState{}).
-		WithEventFilter(onCreationOrUpdateForThisNNS).
-		Complete(r)
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&nmstatev1beta1.NodeNetworkState{}, builder.WithPredicates(onCreationOrUpdateForThisNNS))
+
+	// With a per interface layout the link health is only at the NodeNetworkInterfaces
+	if r.InterfaceMetrics != nil {
+		r.oldLinkHealthKeys = make(map[string]map[linkHealthKey]struct{})
+		controllerBuilder = controllerBuilder.Watches(
+			&nmstatev1beta1.NodeNetworkInterface{},
+			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, nni client.Object) []reconcile.Request {
+				nodeName, hasNode := nni.GetLabels()[shared.NodeNetworkInterfaceNodeLabel]
+				if !hasNode {
+					return nil
+				}
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: nodeName}}}
+			}),
+		)
+	}
+
+	err := controllerBuilder.Complete(r)
 	if err != nil {
 		return errors.Wrap(err, "failed to add controller to NNS metrics Reconciler")
 	}
@@ -190,6 +258,73 @@ func (r *NodeNetworkStateReconciler) updateNodeRouteMetrics(nodeName string, cou
 	r.oldRouteKeys[nodeName] = newKeys
 }
 
+// updateNodeLinkHealthMetrics sets the per interface metrics for a specific node
+func (r *NodeNetworkStateReconciler) updateNodeLinkHealthMetrics(nodeName string, linksHealth []state.LinkHealth) {
+	selectedLinksHealth, dropped := state.SelectLinksHealth(linksHealth, r.InterfaceMetrics)
+	monitoring.InterfaceMetricsDropped.WithLabelValues(nodeName).Set(float64(dropped))
+
+	oldKeys := r.oldLinkHealthKeys[nodeName]
+	newKeys := make(map[linkHealthKey]struct{})
+	for _, linkHealth := range selectedLinksHealth {
+		setLinkHealthMetrics(nodeName, linkHealth)
+		newKeys[linkHealthKey{name: linkHealth.Name, ifaceType: linkHealth.Type}] = struct{}{}
+	}
+
+	// Delete metrics for interfaces that are no longer reported on this node
+	for oldKey := range oldKeys {
+		if _, exists := newKeys[oldKey]; !exists {
+			deleteLinkHealthMetrics(nodeName, oldKey)
+		}
+	}
+
+	r.oldLinkHealthKeys[nodeName] = newKeys
+}
+
+func setLinkHealthMetrics(nodeName string, linkHealth state.LinkHealth) {
+	labels := prometheus.Labels{"node": nodeName, "interface": linkHealth.Name, "type": linkHealth.Type}
+	operState := 0.0
+	if linkHealth.Up {
+		operState = 1
+	}
+	monitoring.InterfaceOperState.With(labels).Set(operState)
+
+	if linkHealth.MTU > 0 {
+		monitoring.InterfaceMTU.With(labels).Set(float64(linkHealth.MTU))
+	} else {
+		monitoring.InterfaceMTU.Delete(labels)
+	}
+
+	if linkHealth.SpeedMbps > 0 {
+		// Mb/s to bytes per second
+		monitoring.InterfaceSpeed.With(labels).Set(float64(linkHealth.SpeedMbps) * 1000 * 1000 / 8)
+	} else {
+		monitoring.InterfaceSpeed.Delete(labels)
+	}
+
+	if linkHealth.LLDPEnabled {
+		monitoring.InterfaceLLDPNeighbors.With(labels).Set(float64(linkHealth.LLDPNeighbors))
+	} else {
+		monitoring.InterfaceLLDPNeighbors.Delete(labels)
+	}
+
+	if linkHealth.IsBond {
+		monitoring.BondPorts.WithLabelValues(nodeName, linkHealth.Name, "active").Set(float64(linkHealth.BondActivePorts))
+		monitoring.BondPorts.WithLabelValues(nodeName, linkHealth.Name, "inactive").Set(float64(linkHealth.BondInactivePorts))
+	}
+}
+
+func deleteLinkHealthMetrics(nodeName string, key linkHealthKey) {
+	labels := prometheus.Labels{"node": nodeName, "interface": key.name, "type": key.ifaceType}
+	monitoring.InterfaceOperState.Delete(labels)
+	monitoring.InterfaceMTU.Delete(labels)
+	monitoring.InterfaceSpeed.Delete(labels)
+	monitoring.InterfaceLLDPNeighbors.Delete(labels)
+	if key.ifaceType == "bond" {
+		monitoring.BondPorts.Delete(prometheus.Labels{"node": nodeName, "interface": key.name, "state": "active"})
+		monitoring.BondPorts.Delete(prometheus.Labels{"node": nodeName, "interface": key.name, "state": "inactive"})
+	}
+}
+
 // deleteNodeMetrics removes all interface and route count metrics for a specific node
 func (r *NodeNetworkStateReconciler) deleteNodeMetrics(nodeName string) {
 	// Delete interface metrics
@@ -214,4 +349,50 @@ func (r *NodeNetworkStateReconciler) deleteNodeMetrics(nodeName string) {
 		}
 		delete(r.oldRouteKeys, nodeName)
 	}
+
+	// Delete per interface metrics
+	if oldKeys, ok := r.oldLinkHealthKeys[nodeName]; ok {
+		for key := range oldKeys {
+			deleteLinkHealthMetrics(nodeName, key)
+		}
+		delete(r.oldLinkHealthKeys, nodeName)
+		monitoring.InterfaceMetricsDropped.Delete(prometheus.Labels{"node": nodeName})
+	}
+
+	// Delete health metrics
+	for _, conditionType := range shared.NodeNetworkStateHealthConditions {
+		monitoring.HealthConditions.Delete(prometheus.Labels{"node": nodeName, "condition": string(conditionType)})
+	}
+}
+
+// updateNodeHealthMetrics exposes the NodeNetworkState health conditions, the
+// ones not reported yet are removed.
+func updateNodeHealthMetrics(nodeName string, conditions shared.ConditionList) {
+	for _, conditionType := range shared.NodeNetworkStateHealthConditions {
+		labels := prometheus.Labels{"node": nodeName, "condition": string(conditionType)}
+		condition := conditions.Find(conditionType)
+		if condition == nil {
+			monitoring.HealthConditions.Delete(labels)
+			continue
+		}
+		value := 0.0
+		if condition.Status == corev1.ConditionTrue {
+			value = 1
+		}
+		monitoring.HealthConditions.With(labels).Set(value)
+	}
+}
+
+func healthChanged(oldConditions, newConditions shared.ConditionList) bool {
+	for _, conditionType := range shared.NodeNetworkStateHealthConditions {
+		oldCondition := oldConditions.Find(conditionType)
+		newCondition := newConditions.Find(conditionType)
+		if (oldCondition == nil) != (newCondition == nil) {
+			return true
+		}
+		if oldCondition != nil && oldCondition.Status != newCondition.Status {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,87 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nmstatev1 "github.com/nmstate/kubernetes-nmstate/api/v1"
+	"github.com/nmstate/kubernetes-nmstate/pkg/truncate"
+)
+
+const (
+	// ManifestDrifted is the reason of the events emitted when fields owned by
+	// the operator are changed by another field manager
+	ManifestDrifted = "ManifestDrifted"
+	// maxDriftMessageLength bounds the drift event messages
+	maxDriftMessageLength = 1024
+)
+
+// apply server-side applies a rendered object with the operator field
+// manager. The object is applied without forcing first, so the operator owned
+// fields changed by someone else are reported as drift before the operator
+// takes them back. Fields the operator does not render, like labels and
+// annotations added by the admins, are kept by server-side apply.
+func (r *NMStateReconciler) apply(ctx context.Context, instance *nmstatev1.NMState, obj *unstructured.Unstructured) error {
+	//nolint:staticcheck // TODO: migrate to client.Client.Apply()
+	err := r.Patch(ctx, obj, client.Apply, nmstateOperatorFieldOwner)
+	if err == nil {
+		return nil
+	}
+	drift := ownedFieldsDrift(err)
+	if len(drift) == 0 {
+		return err
+	}
+	r.reportDrift(instance, obj, drift)
+	//nolint:staticcheck // TODO: migrate to client.Client.Apply()
+	return r.Patch(ctx, obj, client.Apply, nmstateOperatorFieldOwner, client.ForceOwnership)
+}
+
+// ownedFieldsDrift returns the fields of a server-side apply conflict with
+// the manager that changed them.
+func ownedFieldsDrift(err error) []string {
+	status, ok := err.(apierrors.APIStatus)
+	if !ok || !apierrors.IsConflict(err) || status.Status().Details == nil {
+		return nil
+	}
+	drift := []string{}
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		drift = append(drift, fmt.Sprintf("%s (%s)", cause.Field, cause.Message))
+	}
+	return drift
+}
+
+func (r *NMStateReconciler) reportDrift(instance *nmstatev1.NMState, obj *unstructured.Unstructured, drift []string) {
+	message := fmt.Sprintf("%s %s fields owned by the operator changed, restoring them: %s",
+		obj.GetKind(), client.ObjectKeyFromObject(obj), strings.Join(drift, ", "))
+	message = truncate.Head(message, maxDriftMessageLength, "...")
+	r.Log.Info("Operator owned fields drifted", "kind", obj.GetKind(), "name", obj.GetName(),
+		"namespace", obj.GetNamespace(), "fields", drift)
+	r.recordEvent(instance, corev1.EventTypeWarning, ManifestDrifted, message)
+}
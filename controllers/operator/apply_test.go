@@ -0,0 +1,102 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	nmstatev1 "github.com/nmstate/kubernetes-nmstate/api/v1"
+)
+
+var _ = Describe("Operator manifests apply", func() {
+	var (
+		reconciler *NMStateReconciler
+		recorder   *record.FakeRecorder
+		nmstate    = &nmstatev1.NMState{ObjectMeta: metav1.ObjectMeta{Name: "nmstate"}}
+		forced     []bool
+		applyErr   error
+		deployment = func() *unstructured.Unstructured {
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+			obj.SetNamespace("nmstate")
+			obj.SetName("nmstate-webhook")
+			return obj
+		}
+	)
+	BeforeEach(func() {
+		forced = []bool{}
+		applyErr = nil
+		recorder = record.NewFakeRecorder(10)
+		cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(_ context.Context, _ client.WithWatch, _ client.Object, _ client.Patch, opts ...client.PatchOption) error {
+				patchOptions := &client.PatchOptions{}
+				patchOptions.ApplyOptions(opts)
+				force := patchOptions.Force != nil && *patchOptions.Force
+				forced = append(forced, force)
+				if force {
+					return nil
+				}
+				return applyErr
+			},
+		}).Build()
+		reconciler = &NMStateReconciler{Client: cl, APIClient: cl, Log: logr.Discard(), Recorder: recorder}
+	})
+
+	It("should apply without forcing when nobody else changed the owned fields", func() {
+		Expect(reconciler.apply(context.Background(), nmstate, deployment())).To(Succeed())
+		Expect(forced).To(Equal([]bool{false}))
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("should report the drifted fields and take them back", func() {
+		applyErr = apierrors.NewApplyConflict([]metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kubectl-edit" using apps/v1`,
+			Field:   ".spec.template.spec.priorityClassName",
+		}}, "Apply failed with 1 conflict")
+		Expect(reconciler.apply(context.Background(), nmstate, deployment())).To(Succeed())
+		Expect(forced).To(Equal([]bool{false, true}))
+		Expect(recorder.Events).To(Receive(SatisfyAll(
+			ContainSubstring(ManifestDrifted),
+			ContainSubstring("nmstate/nmstate-webhook"),
+			ContainSubstring(`.spec.template.spec.priorityClassName (conflict with "kubectl-edit" using apps/v1)`),
+		)))
+	})
+
+	It("should return the errors that are not field manager conflicts", func() {
+		applyErr = apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "nmstate-webhook",
+			errors.New("object was modified"))
+		Expect(reconciler.apply(context.Background(), nmstate, deployment())).To(MatchError(applyErr))
+		Expect(forced).To(Equal([]bool{false}))
+		Expect(recorder.Events).To(BeEmpty())
+	})
+})
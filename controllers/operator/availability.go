@@ -0,0 +1,84 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+// availabilityData is the PodDisruptionBudget and topology spread of a
+// Deployment with the NMState overrides applied over the operator defaults.
+type availabilityData struct {
+	PodDisruptionBudget       shared.PodDisruptionBudgetConfiguration
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint
+}
+
+// newAvailabilityData defaults the budget to minAvailable pods and spreads the
+// pods matching podLabels across the nodes. The constraints without a label
+// selector select the pods too.
+func newAvailabilityData(overrides *shared.DeploymentAvailability, minAvailable int, podLabels map[string]string) availabilityData {
+	data := availabilityData{
+		PodDisruptionBudget: shared.PodDisruptionBudgetConfiguration{
+			MinAvailable: ptr.To(intstr.FromInt32(int32(minAvailable))),
+		},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelHostname,
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+		}},
+	}
+	if overrides != nil {
+		if budget := overrides.PodDisruptionBudget; budget != nil && (budget.MinAvailable != nil || budget.MaxUnavailable != nil) {
+			data.PodDisruptionBudget = *budget
+		}
+		if len(overrides.TopologySpreadConstraints) > 0 {
+			data.TopologySpreadConstraints = make([]corev1.TopologySpreadConstraint, len(overrides.TopologySpreadConstraints))
+			for i := range overrides.TopologySpreadConstraints {
+				overrides.TopologySpreadConstraints[i].DeepCopyInto(&data.TopologySpreadConstraints[i])
+			}
+		}
+	}
+	for i := range data.TopologySpreadConstraints {
+		if data.TopologySpreadConstraints[i].LabelSelector == nil {
+			data.TopologySpreadConstraints[i].LabelSelector = &metav1.LabelSelector{MatchLabels: podLabels}
+		}
+	}
+	return data
+}
+
+// nodeTaintsChangedPredicate passes the node updates changing the taints,
+// they are part of the infra nodes count that sizes the webhook.
+func nodeTaintsChangedPredicate() predicate.TypedPredicate[*corev1.Node] {
+	return predicate.TypedFuncs[*corev1.Node]{
+		CreateFunc:  func(event.TypedCreateEvent[*corev1.Node]) bool { return false },
+		DeleteFunc:  func(event.TypedDeleteEvent[*corev1.Node]) bool { return false },
+		GenericFunc: func(event.TypedGenericEvent[*corev1.Node]) bool { return false },
+		UpdateFunc: func(e event.TypedUpdateEvent[*corev1.Node]) bool {
+			return !reflect.DeepEqual(e.ObjectOld.Spec.Taints, e.ObjectNew.Spec.Taints)
+		},
+	}
+}
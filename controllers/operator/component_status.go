@@ -0,0 +1,137 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/nmstate/kubernetes-nmstate/api/names"
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
+	"github.com/nmstate/kubernetes-nmstate/pkg/environment"
+)
+
+const (
+	componentLabelKey      = "component"
+	componentLabelPrefix   = "kubernetes-nmstate-"
+	consolePluginComponent = "console-plugin"
+)
+
+func deploymentComponentStatus(deployment *appsv1.Deployment) shared.ComponentStatus {
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	image, version := podTemplateImageAndVersion(&deployment.Spec.Template)
+	return shared.ComponentStatus{
+		Name:     componentName(deployment.Name, deployment.Labels),
+		Kind:     "Deployment",
+		Workload: deployment.Name,
+		Desired:  desired,
+		Ready:    deployment.Status.ReadyReplicas,
+		Image:    image,
+		Version:  version,
+	}
+}
+
+func daemonSetComponentStatus(daemonSet *appsv1.DaemonSet) shared.ComponentStatus {
+	image, version := podTemplateImageAndVersion(&daemonSet.Spec.Template)
+	return shared.ComponentStatus{
+		Name:     componentName(daemonSet.Name, daemonSet.Labels),
+		Kind:     "DaemonSet",
+		Workload: daemonSet.Name,
+		Desired:  daemonSet.Status.DesiredNumberScheduled,
+		Ready:    daemonSet.Status.NumberReady,
+		Image:    image,
+		Version:  version,
+	}
+}
+
+// componentName returns the component label without the kubernetes-nmstate
+// prefix, the console plugin Deployment is recognized by its name.
+func componentName(name string, labels map[string]string) string {
+	if component, ok := labels[componentLabelKey]; ok {
+		return strings.TrimPrefix(component, componentLabelPrefix)
+	}
+	if name == environment.GetEnvVar("PLUGIN_NAME", "nmstate-console-plugin") {
+		return consolePluginComponent
+	}
+	return name
+}
+
+// podTemplateImageAndVersion returns the first container image and the
+// version label of the pod template, or the image tag if there is no label.
+func podTemplateImageAndVersion(template *corev1.PodTemplateSpec) (image, version string) {
+	if len(template.Spec.Containers) > 0 {
+		image = template.Spec.Containers[0].Image
+	}
+	if version = template.Labels[names.VersionLabelKey]; version != "" {
+		return image, version
+	}
+	return image, imageTag(image)
+}
+
+func imageTag(image string) string {
+	if _, digest, found := strings.Cut(image, "@"); found {
+		return digest
+	}
+	// The last colon is the tag separator only if it is after the last slash,
+	// otherwise it is the registry port.
+	colon := strings.LastIndex(image, ":")
+	if colon < 0 || colon < strings.LastIndex(image, "/") {
+		return ""
+	}
+	return image[colon+1:]
+}
+
+// nodeVersions returns the spread of the handler nmstate and host
+// NetworkManager versions reported at the NodeNetworkStates, they are read
+// from the cache so every reconcile does not list them from the API server.
+func (r *NMStateReconciler) nodeVersions(ctx context.Context) (nmstate, networkManager []shared.VersionCount, err error) {
+	nodeNetworkStates := nmstatev1beta1.NodeNetworkStateList{}
+	if err := r.List(ctx, &nodeNetworkStates); err != nil {
+		return nil, nil, fmt.Errorf("failed listing node network states: %w", err)
+	}
+	nmstateNodes := map[string]int32{}
+	networkManagerNodes := map[string]int32{}
+	for i := range nodeNetworkStates.Items {
+		status := &nodeNetworkStates.Items[i].Status
+		if status.HandlerNmstateVersion != "" {
+			nmstateNodes[status.HandlerNmstateVersion]++
+		}
+		if status.HostNetworkManagerVersion != "" {
+			networkManagerNodes[status.HostNetworkManagerVersion]++
+		}
+	}
+	return versionCounts(nmstateNodes), versionCounts(networkManagerNodes), nil
+}
+
+func versionCounts(nodes map[string]int32) []shared.VersionCount {
+	counts := make([]shared.VersionCount, 0, len(nodes))
+	for version, count := range nodes {
+		counts = append(counts, shared.VersionCount{Version: version, Nodes: count})
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Version < counts[j].Version })
+	return counts
+}
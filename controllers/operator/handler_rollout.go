@@ -0,0 +1,268 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
+	"github.com/nmstate/kubernetes-nmstate/pkg/node"
+)
+
+const (
+	// handlerRolloutRequeue is how often an unfinished handler rollout is checked
+	handlerRolloutRequeue = 10 * time.Second
+	// handlerReadinessTimeout holds a rollout when an updated handler pod is
+	// not ready after it
+	handlerReadinessTimeout = 5 * time.Minute
+	// handlerRolloutMaxUnavailablePercent is the percentage of the handler
+	// pods restarted at the same time, at least one
+	handlerRolloutMaxUnavailablePercent = 10
+)
+
+// rolloutHandlers restarts the outdated pods of the OnDelete handler
+// DaemonSets. A pod is only restarted when its node is not configuring a
+// policy, and the rollout is held while an updated pod does not get ready. The
+// DaemonSets status changes trigger the next steps, the returned requeue is
+// set to check again the busy nodes, the updated pods not ready yet and the
+// held rollouts.
+func (r *NMStateReconciler) rolloutHandlers(
+	ctx context.Context,
+	now time.Time,
+) (rollouts []shared.HandlerRolloutStatus, requeue bool, err error) {
+	rollouts = []shared.HandlerRolloutStatus{}
+	for _, daemonSetKey := range r.daemonSets {
+		daemonSet := &appsv1.DaemonSet{}
+		if err := r.Get(ctx, daemonSetKey, daemonSet); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, false, fmt.Errorf("failed getting handler daemonset %s: %w", daemonSetKey.Name, err)
+		}
+		rollout, waiting, err := r.rolloutHandler(ctx, daemonSet, now)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed rolling out handler daemonset %s: %w", daemonSet.Name, err)
+		}
+		rollouts = append(rollouts, rollout)
+		requeue = requeue || waiting
+	}
+	return rollouts, requeue, nil
+}
+
+func (r *NMStateReconciler) rolloutHandler(
+	ctx context.Context,
+	daemonSet *appsv1.DaemonSet,
+	now time.Time,
+) (shared.HandlerRolloutStatus, bool, error) {
+	rollout := shared.HandlerRolloutStatus{
+		DaemonSet: daemonSet.Name,
+		State:     shared.HandlerRolloutStateRollingOut,
+		Desired:   daemonSet.Status.DesiredNumberScheduled,
+	}
+
+	updateRevision, err := r.daemonSetUpdateRevision(ctx, daemonSet)
+	if err != nil {
+		return rollout, false, err
+	}
+	if updateRevision == "" {
+		if rollout.Desired == 0 {
+			rollout.State = shared.HandlerRolloutStateRolledOut
+		} else {
+			rollout.Message = "waiting for the DaemonSet revision"
+		}
+		return rollout, false, nil
+	}
+
+	pods := corev1.PodList{}
+	if err := r.APIClient.List(ctx, &pods,
+		client.InNamespace(daemonSet.Namespace),
+		client.MatchingLabels(daemonSet.Spec.Selector.MatchLabels),
+	); err != nil {
+		return rollout, false, fmt.Errorf("failed listing handler pods: %w", err)
+	}
+
+	outdated := []corev1.Pod{}
+	waitingForReadiness := false
+	running := int32(0)
+	unavailable := int32(0)
+	for i := range pods.Items {
+		pod := pods.Items[i]
+		if !metav1.IsControlledBy(&pod, daemonSet) {
+			continue
+		}
+		if pod.DeletionTimestamp != nil {
+			unavailable++
+			continue
+		}
+		running++
+		ready := isPodReady(&pod)
+		if !ready {
+			unavailable++
+		}
+		if pod.Labels[appsv1.DefaultDaemonSetUniqueLabelKey] != updateRevision {
+			outdated = append(outdated, pod)
+			continue
+		}
+		rollout.Updated++
+		if ready {
+			continue
+		}
+		if now.Sub(pod.CreationTimestamp.Time) > handlerReadinessTimeout {
+			rollout.State = shared.HandlerRolloutStateHeld
+			rollout.Message = fmt.Sprintf("updated handler pod %s at node %s is not ready after %s",
+				pod.Name, pod.Spec.NodeName, handlerReadinessTimeout)
+		} else {
+			waitingForReadiness = true
+		}
+	}
+	if rollout.State == shared.HandlerRolloutStateHeld {
+		// The pod readiness does not change the DaemonSet status again
+		return rollout, true, nil
+	}
+	unavailable += max(0, rollout.Desired-running)
+	waitingForReadiness = waitingForReadiness && len(outdated) > 0
+
+	if len(outdated) == 0 {
+		if rollout.Updated >= rollout.Desired {
+			rollout.State = shared.HandlerRolloutStateRolledOut
+		} else {
+			rollout.Message = fmt.Sprintf("waiting for %d handler pods to be created", rollout.Desired-rollout.Updated)
+		}
+		return rollout, false, nil
+	}
+
+	maxUnavailable := max(1, rollout.Desired*handlerRolloutMaxUnavailablePercent/100)
+	sort.Slice(outdated, func(i, j int) bool { return outdated[i].Spec.NodeName < outdated[j].Spec.NodeName })
+	restarted := []string{}
+	busy := []string{}
+	for i := range outdated {
+		pod := &outdated[i]
+		ready := isPodReady(pod)
+		if ready && unavailable >= maxUnavailable {
+			break
+		}
+		// Checked just before restarting the pod, a policy the node starts
+		// later is retried by the restarted handler since the interrupted
+		// apply is rolled back by nmstate
+		configuring, err := r.nodeIsConfiguringPolicy(ctx, daemonSet.Namespace, pod.Spec.NodeName, now)
+		if err != nil {
+			return rollout, false, err
+		}
+		if configuring {
+			busy = append(busy, pod.Spec.NodeName)
+			continue
+		}
+		err = r.APIClient.Delete(ctx, pod, client.Preconditions{UID: &pod.UID})
+		if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+			return rollout, false, fmt.Errorf("failed restarting handler pod %s: %w", pod.Name, err)
+		}
+		r.Log.Info("Restarted outdated handler pod", "pod", pod.Name, "node", pod.Spec.NodeName)
+		restarted = append(restarted, pod.Spec.NodeName)
+		if ready {
+			unavailable++
+		}
+	}
+
+	messages := []string{fmt.Sprintf("%d handler pods outdated", len(outdated)-len(restarted))}
+	if len(restarted) > 0 {
+		messages = append(messages, "restarted the handler at nodes "+strings.Join(restarted, ", "))
+	}
+	if len(busy) > 0 {
+		messages = append(messages, "waiting for the policies being configured at nodes "+strings.Join(busy, ", "))
+	}
+	rollout.Message = strings.Join(messages, ", ")
+	return rollout, len(busy) > 0 || waitingForReadiness, nil
+}
+
+// daemonSetUpdateRevision returns the hash of the latest revision of the
+// DaemonSet pod template, the pods running it have the same
+// controller-revision-hash label.
+func (r *NMStateReconciler) daemonSetUpdateRevision(ctx context.Context, daemonSet *appsv1.DaemonSet) (string, error) {
+	revisions := appsv1.ControllerRevisionList{}
+	if err := r.APIClient.List(ctx, &revisions,
+		client.InNamespace(daemonSet.Namespace),
+		client.MatchingLabels(daemonSet.Spec.Selector.MatchLabels),
+	); err != nil {
+		return "", fmt.Errorf("failed listing handler daemonset revisions: %w", err)
+	}
+	var latest *appsv1.ControllerRevision
+	for i := range revisions.Items {
+		revision := &revisions.Items[i]
+		if !metav1.IsControlledBy(revision, daemonSet) {
+			continue
+		}
+		if latest == nil || revision.Revision > latest.Revision {
+			latest = revision
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	return latest.Labels[appsv1.DefaultDaemonSetUniqueLabelKey], nil
+}
+
+// nodeIsConfiguringPolicy returns if the node holds a maxUnavailable slot or
+// has a Progressing enactment. The handler takes the slot before setting the
+// enactment Progressing and releases it after, so the slots are checked last.
+func (r *NMStateReconciler) nodeIsConfiguringPolicy(ctx context.Context, handlerNamespace, nodeName string, now time.Time) (bool, error) {
+	enactments := nmstatev1beta1.NodeNetworkConfigurationEnactmentList{}
+	if err := r.APIClient.List(ctx, &enactments, client.MatchingLabels{shared.EnactmentNodeLabel: nodeName}); err != nil {
+		return false, fmt.Errorf("failed listing enactments at node %s: %w", nodeName, err)
+	}
+	for i := range enactments.Items {
+		condition := enactments.Items[i].Status.Conditions.Find(shared.NodeNetworkConfigurationEnactmentConditionProgressing)
+		if condition != nil && condition.Status == corev1.ConditionTrue {
+			return true, nil
+		}
+	}
+
+	leases := coordinationv1.LeaseList{}
+	if err := r.APIClient.List(ctx, &leases,
+		client.InNamespace(handlerNamespace),
+		client.MatchingLabels{shared.EnactmentNodeLabel: nodeName},
+	); err != nil {
+		return false, fmt.Errorf("failed listing maxUnavailable slots of node %s: %w", nodeName, err)
+	}
+	for i := range leases.Items {
+		if node.IsConfiguring(&leases.Items[i], nodeName, now) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
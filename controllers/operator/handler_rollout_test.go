@@ -0,0 +1,225 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
+)
+
+var _ = Describe("Handler rollout", func() {
+	const (
+		namespace       = "nmstate"
+		currentRevision = "current"
+		oldRevision     = "old"
+	)
+	var (
+		now        = time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)
+		reconciler *NMStateReconciler
+		cl         client.Client
+		daemonSet  = &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "nmstate-handler", UID: "handler-uid"},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"name": "nmstate-handler"}},
+			},
+			Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3},
+		}
+		controllerRef = func() []metav1.OwnerReference {
+			return []metav1.OwnerReference{*metav1.NewControllerRef(daemonSet, appsv1.SchemeGroupVersion.WithKind("DaemonSet"))}
+		}
+		revision = func(hash string, number int64) *appsv1.ControllerRevision {
+			return &appsv1.ControllerRevision{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       namespace,
+					Name:            "nmstate-handler-" + hash,
+					Labels:          map[string]string{"name": "nmstate-handler", appsv1.DefaultDaemonSetUniqueLabelKey: hash},
+					OwnerReferences: controllerRef(),
+				},
+				Revision: number,
+			}
+		}
+		pod = func(node, hash string, ready bool, created time.Time) *corev1.Pod {
+			readyStatus := corev1.ConditionFalse
+			if ready {
+				readyStatus = corev1.ConditionTrue
+			}
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         namespace,
+					Name:              "nmstate-handler-" + node,
+					Labels:            map[string]string{"name": "nmstate-handler", appsv1.DefaultDaemonSetUniqueLabelKey: hash},
+					OwnerReferences:   controllerRef(),
+					CreationTimestamp: metav1.NewTime(created),
+				},
+				Spec: corev1.PodSpec{NodeName: node},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+				},
+			}
+		}
+		progressingEnactment = func(node string) *nmstatev1beta1.NodeNetworkConfigurationEnactment {
+			enactment := &nmstatev1beta1.NodeNetworkConfigurationEnactment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   node + ".policy",
+					Labels: map[string]string{shared.EnactmentNodeLabel: node},
+				},
+			}
+			enactment.Status.Conditions.Set(shared.NodeNetworkConfigurationEnactmentConditionProgressing,
+				corev1.ConditionTrue, shared.NodeNetworkConfigurationEnactmentConditionConfigurationProgressing, "")
+			return enactment
+		}
+		slotLease = func(node string, renewed time.Time) *coordinationv1.Lease {
+			return &coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "policy-slot-" + node,
+					Labels:    map[string]string{shared.EnactmentNodeLabel: node},
+				},
+				Spec: coordinationv1.LeaseSpec{
+					HolderIdentity:       ptr.To(node),
+					LeaseDurationSeconds: ptr.To(int32(120)),
+					RenewTime:            &metav1.MicroTime{Time: renewed},
+				},
+			}
+		}
+		podExists = func(node string) bool {
+			err := cl.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "nmstate-handler-" + node}, &corev1.Pod{})
+			if apierrors.IsNotFound(err) {
+				return false
+			}
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			return true
+		}
+		setup = func(objs ...runtime.Object) {
+			s := scheme.Scheme
+			s.AddKnownTypes(nmstatev1beta1.GroupVersion,
+				&nmstatev1beta1.NodeNetworkConfigurationEnactment{},
+				&nmstatev1beta1.NodeNetworkConfigurationEnactmentList{},
+			)
+			objs = append(objs, daemonSet.DeepCopy(), revision(oldRevision, 1), revision(currentRevision, 2))
+			cl = fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()
+			reconciler = &NMStateReconciler{
+				Client:     cl,
+				APIClient:  cl,
+				Log:        logr.Discard(),
+				daemonSets: []client.ObjectKey{client.ObjectKeyFromObject(daemonSet)},
+			}
+		}
+	)
+
+	It("should restart the outdated pods at the nodes without Progressing enactments", func() {
+		setup(
+			pod("node01", oldRevision, true, now.Add(-time.Hour)),
+			pod("node02", oldRevision, true, now.Add(-time.Hour)),
+			pod("node03", oldRevision, true, now.Add(-time.Hour)),
+			progressingEnactment("node01"),
+		)
+		rollouts, requeue, err := reconciler.rolloutHandlers(context.Background(), now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeue).To(BeTrue())
+		Expect(podExists("node01")).To(BeTrue())
+		Expect(podExists("node02")).To(BeFalse())
+		Expect(podExists("node03")).To(BeTrue())
+		Expect(rollouts).To(ConsistOf(shared.HandlerRolloutStatus{
+			DaemonSet: "nmstate-handler",
+			State:     shared.HandlerRolloutStateRollingOut,
+			Desired:   3,
+			Message: "2 handler pods outdated, restarted the handler at nodes node02, " +
+				"waiting for the policies being configured at nodes node01",
+		}))
+	})
+	It("should not restart the pods at the nodes holding a maxUnavailable slot", func() {
+		setup(
+			pod("node01", oldRevision, true, now.Add(-time.Hour)),
+			pod("node02", oldRevision, true, now.Add(-time.Hour)),
+			pod("node03", oldRevision, true, now.Add(-time.Hour)),
+			slotLease("node01", now),
+			slotLease("node02", now.Add(-time.Hour)),
+		)
+		rollouts, requeue, err := reconciler.rolloutHandlers(context.Background(), now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeue).To(BeTrue())
+		Expect(podExists("node01")).To(BeTrue())
+		Expect(podExists("node02")).To(BeFalse(), "the expired slot should not keep the node busy")
+		Expect(rollouts[0].Message).To(ContainSubstring("waiting for the policies being configured at nodes node01"))
+	})
+	It("should hold the rollout when an updated pod does not get ready", func() {
+		setup(
+			pod("node01", currentRevision, false, now.Add(-10*time.Minute)),
+			pod("node02", oldRevision, true, now.Add(-time.Hour)),
+			pod("node03", oldRevision, true, now.Add(-time.Hour)),
+		)
+		rollouts, requeue, err := reconciler.rolloutHandlers(context.Background(), now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeue).To(BeTrue())
+		Expect(podExists("node02")).To(BeTrue())
+		Expect(podExists("node03")).To(BeTrue())
+		Expect(rollouts).To(HaveLen(1))
+		Expect(rollouts[0].State).To(Equal(shared.HandlerRolloutStateHeld))
+		Expect(rollouts[0].Updated).To(BeEquivalentTo(1))
+		Expect(rollouts[0].Message).To(ContainSubstring("nmstate-handler-node01 at node node01 is not ready"))
+	})
+	It("should wait for a recently updated pod to get ready", func() {
+		setup(
+			pod("node01", currentRevision, false, now.Add(-time.Minute)),
+			pod("node02", oldRevision, true, now.Add(-time.Hour)),
+			pod("node03", oldRevision, true, now.Add(-time.Hour)),
+		)
+		rollouts, requeue, err := reconciler.rolloutHandlers(context.Background(), now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeue).To(BeTrue())
+		Expect(podExists("node02")).To(BeTrue())
+		Expect(podExists("node03")).To(BeTrue())
+		Expect(rollouts[0].State).To(Equal(shared.HandlerRolloutStateRollingOut))
+	})
+	It("should report the rollout finished when every pod is updated", func() {
+		setup(
+			pod("node01", currentRevision, true, now.Add(-time.Minute)),
+			pod("node02", currentRevision, true, now.Add(-time.Minute)),
+			pod("node03", currentRevision, true, now.Add(-time.Minute)),
+		)
+		rollouts, requeue, err := reconciler.rolloutHandlers(context.Background(), now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeue).To(BeFalse())
+		Expect(rollouts).To(ConsistOf(shared.HandlerRolloutStatus{
+			DaemonSet: "nmstate-handler",
+			State:     shared.HandlerRolloutStateRolledOut,
+			Desired:   3,
+			Updated:   3,
+		}))
+	})
+})
@@ -0,0 +1,130 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstatev1 "github.com/nmstate/kubernetes-nmstate/api/v1"
+	"github.com/nmstate/kubernetes-nmstate/pkg/environment"
+	"github.com/nmstate/kubernetes-nmstate/pkg/render"
+)
+
+// manifestOverlayError is an overlay that failed patching the rendered
+// manifests
+type manifestOverlayError struct {
+	error
+}
+
+// manifestOverlays returns the validated overlays of the NMState ConfigMap
+func (r *NMStateReconciler) manifestOverlays(ctx context.Context, instance *nmstatev1.NMState) ([]render.Overlay, error) {
+	if instance.Spec.ManifestOverlays == nil {
+		return nil, nil
+	}
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{
+		Namespace: environment.GetEnvVar("OPERATOR_NAMESPACE", ""),
+		Name:      instance.Spec.ManifestOverlays.Name,
+	}
+	if err := r.Get(ctx, key, configMap); err != nil {
+		return nil, manifestOverlayError{fmt.Errorf("failed getting manifest overlays configmap %s: %w", key, err)}
+	}
+	overlays, err := render.ParseOverlays(configMap.Data)
+	if err != nil {
+		return nil, manifestOverlayError{fmt.Errorf("manifest overlays configmap %s: %w", key, err)}
+	}
+	return overlays, nil
+}
+
+// applyManifestOverlays patches the rendered objects and remembers the
+// overlays that matched any of them.
+func (r *NMStateReconciler) applyManifestOverlays(objs []*unstructured.Unstructured) error {
+	if len(r.overlays) == 0 {
+		return nil
+	}
+	matched, err := render.ApplyOverlays(objs, r.overlays)
+	if err != nil {
+		return manifestOverlayError{err}
+	}
+	r.matchedOverlays.Insert(matched...)
+	return nil
+}
+
+// setManifestOverlaysCondition reports the overlays of the reconcile, the
+// manifests are not applied if an overlay failed.
+func (r *NMStateReconciler) setManifestOverlaysCondition(instance *nmstatev1.NMState, reconcileErr error) {
+	if instance.Spec.ManifestOverlays == nil {
+		instance.Status.Conditions = slices.DeleteFunc(instance.Status.Conditions, func(condition shared.Condition) bool {
+			return condition.Type == shared.NmstateConditionManifestOverlaysApplied
+		})
+		return
+	}
+	if overlayErr := (manifestOverlayError{}); errors.As(reconcileErr, &overlayErr) {
+		instance.Status.Conditions.Set(shared.NmstateConditionManifestOverlaysApplied, corev1.ConditionFalse,
+			shared.NmstateInvalidManifestOverlay, overlayErr.Error())
+		return
+	}
+	if reconcileErr != nil {
+		return
+	}
+	unmatched := []string{}
+	for i := range r.overlays {
+		if !r.matchedOverlays.Has(r.overlays[i].Name) {
+			unmatched = append(unmatched, r.overlays[i].Name)
+		}
+	}
+	if len(unmatched) > 0 {
+		instance.Status.Conditions.Set(shared.NmstateConditionManifestOverlaysApplied, corev1.ConditionFalse,
+			shared.NmstateManifestOverlayUnmatched,
+			fmt.Sprintf("the manifest overlays %s match no manifest", strings.Join(unmatched, ", ")))
+		return
+	}
+	instance.Status.Conditions.Set(shared.NmstateConditionManifestOverlaysApplied, corev1.ConditionTrue,
+		shared.NmstateManifestOverlaysApplied, fmt.Sprintf("%d manifest overlays applied", len(r.overlays)))
+}
+
+// nmstatesWithManifestOverlays enqueues the NMStates with the overlays of a
+// ConfigMap at the operator namespace.
+func (r *NMStateReconciler) nmstatesWithManifestOverlays(ctx context.Context, configMap *corev1.ConfigMap) []ctrl.Request {
+	if configMap.Namespace != environment.GetEnvVar("OPERATOR_NAMESPACE", "") {
+		return nil
+	}
+	instances := nmstatev1.NMStateList{}
+	if err := r.List(ctx, &instances); err != nil {
+		r.Log.Error(err, "failed listing NMStates for the manifest overlays configmap", "configmap", configMap.Name)
+		return nil
+	}
+	requests := []ctrl.Request{}
+	for i := range instances.Items {
+		overlays := instances.Items[i].Spec.ManifestOverlays
+		if overlays != nil && overlays.Name == configMap.Name {
+			requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Name: instances.Items[i].Name}})
+		}
+	}
+	return requests
+}
@@ -22,17 +22,23 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	rbac "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -44,8 +50,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/nmstate/kubernetes-nmstate/api/names"
@@ -53,13 +62,144 @@ import (
 	nmstatev1 "github.com/nmstate/kubernetes-nmstate/api/v1"
 	"github.com/nmstate/kubernetes-nmstate/pkg/environment"
 	"github.com/nmstate/kubernetes-nmstate/pkg/render"
+	"github.com/nmstate/kubernetes-nmstate/pkg/state"
 	nmstatetls "github.com/nmstate/kubernetes-nmstate/pkg/tls"
 )
 
 const (
 	nmstateOperatorFieldOwner = client.FieldOwner("nmstate-operator")
+	// defaultNodeNetworkStateHistorySize is used when the history is enabled without size
+	defaultNodeNetworkStateHistorySize = 10
+	// defaultEnactmentTranscriptsSize is used when the transcripts are enabled without size
+	defaultEnactmentTranscriptsSize = 3
+	// defaultNNCPMaxRetries, defaultNNCPInitialBackoff and defaultNNCPMaxBackoff
+	// are used when neither the NMState reconcileConfiguration nor the
+	// operator environment configures them
+	defaultNNCPMaxRetries     = 5
+	defaultNNCPInitialBackoff = time.Second
+	defaultNNCPMaxBackoff     = 30 * time.Second
+	// defaultAlertFor is used for the PrometheusRule alerts durations not configured
+	defaultAlertFor = 10 * time.Minute
 )
 
+// monitoringData is the PrometheusRule alerts configuration with the
+// defaults applied, the durations are in seconds.
+type monitoringData struct {
+	AlertsEnabled                     bool
+	PolicyDegradedForSeconds          int64
+	EnactmentFailingForSeconds        int64
+	HandlerNotReadyForSeconds         int64
+	NodeNetworkStateStaleAfterSeconds int64
+}
+
+func newMonitoringData(monitoring *shared.Monitoring) monitoringData {
+	if monitoring == nil {
+		monitoring = &shared.Monitoring{}
+	}
+	seconds := func(duration *metav1.Duration) int64 {
+		if duration == nil || duration.Duration <= 0 {
+			return int64(defaultAlertFor.Seconds())
+		}
+		return int64(duration.Seconds())
+	}
+	return monitoringData{
+		AlertsEnabled:                     !monitoring.DisableAlerts,
+		PolicyDegradedForSeconds:          seconds(monitoring.PolicyDegradedFor),
+		EnactmentFailingForSeconds:        seconds(monitoring.EnactmentFailingFor),
+		HandlerNotReadyForSeconds:         seconds(monitoring.HandlerNotReadyFor),
+		NodeNetworkStateStaleAfterSeconds: seconds(monitoring.NodeNetworkStateStaleAfter),
+	}
+}
+
+// componentData is the pod and container configuration of a component with
+// the NMState overrides applied over the operator defaults.
+type componentData struct {
+	Image             string
+	ImagePullPolicy   corev1.PullPolicy
+	PriorityClassName string
+	Resources         corev1.ResourceRequirements
+	ImagePullSecrets  []corev1.LocalObjectReference
+	// Env is rendered after the operator env vars, the ones with the same
+	// name replace them when the manifests are rendered
+	Env []corev1.EnvVar
+}
+
+func newComponentData(overrides *shared.ComponentOverrides, priorityClassName string, requests, limits corev1.ResourceList) componentData {
+	data := componentData{
+		Image:             environment.GetEnvVar("RELATED_IMAGE_HANDLER_IMAGE", ""),
+		ImagePullPolicy:   corev1.PullPolicy(environment.GetEnvVar("HANDLER_IMAGE_PULL_POLICY", "")),
+		PriorityClassName: priorityClassName,
+		Resources:         corev1.ResourceRequirements{Requests: requests, Limits: limits},
+	}
+	if overrides == nil {
+		return data
+	}
+	if overrides.Image != "" {
+		data.Image = overrides.Image
+	}
+	if overrides.ImagePullPolicy != "" {
+		data.ImagePullPolicy = overrides.ImagePullPolicy
+	}
+	if overrides.PriorityClassName != "" {
+		data.PriorityClassName = overrides.PriorityClassName
+	}
+	if overrides.Resources != nil {
+		data.Resources = *overrides.Resources
+	}
+	data.ImagePullSecrets = overrides.ImagePullSecrets
+	data.Env = overrides.Env
+	return data
+}
+
+func resourceList(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+// handlerDaemonSetData is the configuration of a handler DaemonSet, the
+// default one has no pool.
+type handlerDaemonSetData struct {
+	NameSuffix                    string
+	Pool                          string
+	NodeSelector                  map[string]string
+	Tolerations                   []corev1.Toleration
+	Affinity                      *corev1.Affinity
+	LogLevelHandlerCommandArg     string
+	HandlerReadinessProbeExtraArg string
+	ProbeConfiguration            nmstatev1.NMStateProbeConfiguration
+	NNCPMaxRetries                int
+	NNCPInitialBackoffSeconds     int64
+	NNCPMaxBackoffSeconds         int64
+}
+
+// applyRetryPolicy overrides the retries configuration with the fields
+// specified at the retry policy and validates the result.
+func (d *handlerDaemonSetData) applyRetryPolicy(retryPolicy *shared.RetryPolicy) error {
+	if retryPolicy != nil {
+		if retryPolicy.MaxRetries != nil {
+			d.NNCPMaxRetries = int(*retryPolicy.MaxRetries)
+		}
+		if retryPolicy.InitialBackoff != nil {
+			d.NNCPInitialBackoffSeconds = int64(retryPolicy.InitialBackoff.Seconds())
+		}
+		if retryPolicy.MaxBackoff != nil {
+			d.NNCPMaxBackoffSeconds = int64(retryPolicy.MaxBackoff.Seconds())
+		}
+	}
+	if d.NNCPMaxRetries < 1 {
+		return fmt.Errorf("max retries %d has to be at least 1", d.NNCPMaxRetries)
+	}
+	if d.NNCPInitialBackoffSeconds < 1 {
+		return fmt.Errorf("initial backoff %ds has to be at least 1s", d.NNCPInitialBackoffSeconds)
+	}
+	if d.NNCPInitialBackoffSeconds > d.NNCPMaxBackoffSeconds {
+		return fmt.Errorf("initial backoff %ds exceeds max backoff %ds", d.NNCPInitialBackoffSeconds, d.NNCPMaxBackoffSeconds)
+	}
+	return nil
+}
+
 // NMStateReconciler reconciles a NMState object
 type NMStateReconciler struct {
 	client.Client
@@ -67,24 +207,43 @@ type NMStateReconciler struct {
 	Log         logr.Logger
 	Scheme      *runtime.Scheme
 	IsOpenShift bool
+	Recorder    record.EventRecorder
 	deployments []client.ObjectKey
 	daemonSets  []client.ObjectKey
 	// tlsProfileWarning describes cluster TLS profile entries that cannot be
 	// honored; reported as a Degraded condition. Request-scoped.
 	tlsProfileWarning string
+	// instanceClient is the client of the cluster with the NMState when the
+	// manifests are applied at a remote cluster.
+	instanceClient client.Client
+	// remote is set when the manifests are applied at a remote cluster
+	remote bool
+	// remoteInstance is the RemoteNMState the NMState is read from when the
+	// manifests are applied at a remote cluster.
+	remoteInstance *nmstatev1.RemoteNMState
+	// overlays patch the rendered manifests and matchedOverlays are the ones
+	// that matched any of them. Request-scoped.
+	overlays        []render.Overlay
+	matchedOverlays sets.Set[string]
+	// newRemoteClient builds the remote cluster clients, added for test
+	// purposes.
+	newRemoteClient func(kubeconfig []byte, scheme *runtime.Scheme) (client.Client, error)
+	// remoteClients are the remote cluster clients by kubeconfig Secret
+	remoteClients *remoteClients
 }
 
 // Core resources: services, endpoints, events, configmaps need full CRUD for handler deployment manifests.
 // +kubebuilder:rbac:groups="",resources=services;endpoints;events;configmaps,verbs=get;list;watch;create;update;patch;delete
-// Pods are created indirectly via Deployments/DaemonSets — operator only needs read access.
-// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// Pods are created indirectly via Deployments/DaemonSets — operator only deletes the outdated handler pods to roll them out.
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
 // Secrets: operator only deletes one obsolete webhook secret during cleanup; cert-manager (handler SA) handles creation.
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;delete
 // ServiceAccounts: operator creates handler SA.
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 // Namespaces: operator creates handler namespace but never deletes it.
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch
-// +kubebuilder:rbac:groups="",resources=nodes,verbs=list;get
+// Nodes: operator labels the nodes of the handler pools.
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=list;get;watch;patch
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // Admission webhooks: operator manages webhook configurations for the handler.
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingadmissionpolicies;validatingadmissionpolicybindings,verbs=get;list;watch;create;update;patch;delete
@@ -95,12 +254,17 @@ type NMStateReconciler struct {
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings;rolebindings,verbs=get;list;watch;create;update;patch;delete
 // nmstate.io: explicit resources instead of wildcard; includes /status subresources.
 // +kubebuilder:rbac:groups=nmstate.io,resources=nmstates;nodenetworkstates;nodenetworkconfigurationpolicies;nodenetworkconfigurationenactments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=nmstate.io,resources=nodenetworkinterfaces,verbs=get;list;watch;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=nmstate.io,resources=nmstates/finalizers,verbs=update
-// +kubebuilder:rbac:groups=nmstate.io,resources=nmstates/status;nodenetworkstates/status;nodenetworkconfigurationpolicies/status;nodenetworkconfigurationenactments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=nmstate.io,resources=nmstates/status;nodenetworkstates/status;nodenetworkconfigurationpolicies/status;nodenetworkconfigurationenactments/status;nodenetworkinterfaces/status,verbs=get;update;patch
 // CRDs: operator manages the 3 nmstate CRDs — no need for wildcard over all apiextensions resources.
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch;create;update;patch;delete
 // Apps: cluster-scoped for handler DaemonSet and Deployments.
 // +kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;replicasets;statefulsets,verbs=get;list;watch;create;update;patch;delete
+// ControllerRevisions: operator reads the handler DaemonSet revision to find its outdated pods.
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list
+// Leases: operator does not restart the handler of a node holding a policy maxUnavailable slot.
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=list
 // +kubebuilder:rbac:groups="console.openshift.io",resources=consoleplugins,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="operator.openshift.io",resources=consoles,verbs=list;get;watch;update
 // +kubebuilder:rbac:groups="monitoring.coreos.com",resources=servicemonitors;prometheusrules,verbs=list;get;watch;update;create;patch
@@ -131,7 +295,7 @@ func (r *NMStateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, err
 	}
 
-	// We only want one instance of NMState. Ignore anything after that.
+	// We only want one instance of NMState per cluster. Ignore anything after that.
 	if len(instanceList.Items) > 0 {
 		if len(instanceList.Items) > 1 {
 			sort.Slice(instanceList.Items, func(i, j int) bool {
@@ -139,6 +303,10 @@ func (r *NMStateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			})
 		}
 		if instanceList.Items[0].Name != req.Name {
+			if instance.DeletionTimestamp != nil {
+				// The ignored NMState deployed nothing, there is nothing to uninstall
+				return ctrl.Result{}, r.removeUninstallFinalizer(ctx, instance)
+			}
 			r.Log.Info("Ignoring NMState.nmstate.io because one already exists and does not match existing name")
 			err = r.Delete(ctx, instance, &client.DeleteOptions{})
 			if err != nil {
@@ -148,24 +316,62 @@ func (r *NMStateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		}
 	}
 
+	if instance.DeletionTimestamp != nil {
+		return r.finalize(ctx, instance)
+	}
+	if err := r.addUninstallFinalizer(ctx, instance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	overlays, err := r.manifestOverlays(ctx, instance)
+	if err != nil {
+		r.setManifestOverlaysCondition(instance, err)
+		r.setDegradedCondition(ctx, instance, shared.NmstateInvalidManifestOverlay, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	r.overlays = overlays
+	return r.reconcileInstance(ctx, instance)
+}
+
+// reconcileInstance deploys kubernetes-nmstate for the NMState and reports
+// its status.
+func (r *NMStateReconciler) reconcileInstance(ctx context.Context, instance *nmstatev1.NMState) (ctrl.Result, error) {
 	r.deployments = []client.ObjectKey{}
 	r.daemonSets = []client.ObjectKey{}
 	r.tlsProfileWarning = ""
+	r.matchedOverlays = sets.New[string]()
 
 	if err := r.applyManifests(instance, ctx); err != nil {
-		r.setDegradedCondition(ctx, instance, shared.NmstateInternalError, err.Error())
+		r.setManifestOverlaysCondition(instance, err)
+		reason := shared.NmstateInternalError
+		if errors.As(err, &manifestOverlayError{}) {
+			reason = shared.NmstateInvalidManifestOverlay
+		}
+		r.setDegradedCondition(ctx, instance, reason, err.Error())
 		return ctrl.Result{}, err
 	}
+	r.setManifestOverlaysCondition(instance, nil)
 
 	if err := r.cleanupObsoleteResources(ctx); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	handlerRollouts, requeueRollouts, err := r.rolloutHandlers(ctx, time.Now())
+	if err != nil {
+		r.setDegradedCondition(ctx, instance, shared.NmstateInternalError, err.Error())
+		return ctrl.Result{}, err
+	}
+	instance.Status.HandlerRollouts = handlerRollouts
+
 	if err := r.reconcileStatus(ctx, instance); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed reconciling status: %w", err)
 	}
 
 	r.Log.Info("Reconcile complete.")
+	if requeueRollouts {
+		return ctrl.Result{RequeueAfter: handlerRolloutRequeue}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -175,6 +381,26 @@ func (r *NMStateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.DaemonSet{})
 
+	// Watch the node labels to move the nodes between the handler pools and
+	// the node taints to follow the infra nodes count
+	builder = builder.WatchesRawSource(source.Kind(
+		mgr.GetCache(),
+		&corev1.Node{},
+		handler.TypedEnqueueRequestsFromMapFunc(
+			func(ctx context.Context, node *corev1.Node) []ctrl.Request {
+				return []ctrl.Request{{NamespacedName: types.NamespacedName{Name: names.NMStateResourceName}}}
+			},
+		),
+		predicate.Or[*corev1.Node](predicate.TypedLabelChangedPredicate[*corev1.Node]{}, nodeTaintsChangedPredicate()),
+	))
+
+	// Watch the manifest overlays ConfigMaps at the operator namespace
+	builder = builder.WatchesRawSource(source.Kind(
+		mgr.GetCache(),
+		&corev1.ConfigMap{},
+		handler.TypedEnqueueRequestsFromMapFunc(r.nmstatesWithManifestOverlays),
+	))
+
 	// On OpenShift, watch APIServer CR changes to detect TLS profile updates.
 	// This triggers a reconcile that updates the TLS ConfigMap and rolls
 	// webhook/metrics Deployments via a hash annotation.
@@ -196,35 +422,55 @@ func (r *NMStateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		))
 	}
 
-	return builder.Complete(r)
+	if err := builder.Complete(r); err != nil {
+		return err
+	}
+
+	// The objects deployed at the remote clusters are not watched, they are
+	// resynced periodically.
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&nmstatev1.RemoteNMState{}).
+		Named("remotenmstate").
+		Complete(reconcile.Func(r.ReconcileRemote))
 }
 
 func (r *NMStateReconciler) applyManifests(instance *nmstatev1.NMState, ctx context.Context) error {
-	if err := r.applyCRDs(ctx, instance); err != nil {
-		return errors.Wrap(err, "failed applying CRDs")
+	directories, err := r.manifestsDirectories(ctx, instance)
+	if err != nil {
+		return err
 	}
-
-	if err := r.applyNamespace(ctx, instance); err != nil {
-		return errors.Wrap(err, "failed applying Namespace")
+	rendered := make([][]*unstructured.Unstructured, len(directories))
+	all := []*unstructured.Unstructured{}
+	for i, directory := range directories {
+		if rendered[i], err = renderManifests(directory.data, directory.directory); err != nil {
+			return err
+		}
+		all = append(all, rendered[i]...)
 	}
-
-	if err := r.applyRBAC(ctx, instance); err != nil {
-		return errors.Wrap(err, "failed applying RBAC")
+	// The overlays patch every manifest before any is applied, a failing
+	// overlay does not leave them half applied.
+	if err := r.applyManifestOverlays(all); err != nil {
+		return err
 	}
 
-	if err := r.applyNetworkPolicies(ctx, instance); err != nil {
-		return errors.Wrap(err, "failed applying network policies")
+	if err := r.labelHandlerPoolNodes(ctx, instance.Spec.HandlerPools); err != nil {
+		return err
 	}
-
-	if err := r.applyHandler(ctx, instance); err != nil {
-		return errors.Wrap(err, "failed applying Handler")
+	for i, directory := range directories {
+		if err := r.applyObjects(ctx, instance, rendered[i], directory.owned); err != nil {
+			return errors.Wrapf(err, "failed applying %s manifests", directory.directory)
+		}
 	}
-
-	_, errUIPluginPathExists := os.Stat(filepath.Join(names.ManifestDir, "kubernetes-nmstate", "openshift", "ui-plugin"))
-	if r.IsOpenShift && errUIPluginPathExists == nil {
-		if err := r.applyOpenshiftUIPlugin(ctx, instance); err != nil {
-			return errors.Wrap(err, "failed applying UI Plugin")
+	if instance.Spec.HandlerMetrics == nil {
+		if err := r.deleteHandlerMetrics(ctx); err != nil {
+			return err
 		}
+	}
+	if err := r.deleteStaleHandlerPools(ctx, instance.Spec.HandlerPools); err != nil {
+		return err
+	}
+
+	if r.IsOpenShift && uiPluginManifestsExist() {
 		if err := r.patchOpenshiftConsolePlugin(ctx); err != nil {
 			return errors.Wrap(err, "failed enabling the plugin in cluster's console")
 		}
@@ -232,32 +478,53 @@ func (r *NMStateReconciler) applyManifests(instance *nmstatev1.NMState, ctx cont
 	return nil
 }
 
-func (r *NMStateReconciler) applyCRDs(ctx context.Context, instance *nmstatev1.NMState) error {
-	data := render.MakeRenderData()
-	return r.renderAndApply(ctx, instance, data, "crds", false)
+// manifestsDirectory is a manifests directory and the data to render it
+type manifestsDirectory struct {
+	directory string
+	data      render.RenderData
+	// owned objects get the NMState controller reference, they are garbage
+	// collected with it at the local cluster
+	owned bool
 }
 
-func (r *NMStateReconciler) applyNamespace(ctx context.Context, instance *nmstatev1.NMState) error {
-	data := render.MakeRenderData()
-	data.Data["HandlerNamespace"] = environment.GetEnvVar("HANDLER_NAMESPACE", "")
-	data.Data["HandlerPrefix"] = environment.GetEnvVar("HANDLER_PREFIX", "")
-	data.Data["IsOpenShift"] = r.IsOpenShift
-
-	return r.renderAndApply(ctx, instance, data, "namespace", false)
+// manifestsDirectories returns the manifests directories in apply order
+func (r *NMStateReconciler) manifestsDirectories(ctx context.Context, instance *nmstatev1.NMState) ([]manifestsDirectory, error) {
+	namespaceData := render.MakeRenderData()
+	namespaceData.Data["HandlerNamespace"] = environment.GetEnvVar("HANDLER_NAMESPACE", "")
+	namespaceData.Data["HandlerPrefix"] = environment.GetEnvVar("HANDLER_PREFIX", "")
+	namespaceData.Data["IsOpenShift"] = r.IsOpenShift
+	rbacData, err := r.rbacRenderData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	handlerData, err := r.handlerRenderData(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	directories := []manifestsDirectory{
+		{directory: "crds", data: render.MakeRenderData()},
+		{directory: "namespace", data: namespaceData},
+		{directory: "rbac", data: rbacData, owned: true},
+		{directory: "netpol", data: r.networkPoliciesRenderData(), owned: true},
+		{directory: "handler", data: handlerData, owned: true},
+	}
+	if r.IsOpenShift && uiPluginManifestsExist() {
+		directories = append(directories, manifestsDirectory{directory: uiPluginDirectory, data: uiPluginRenderData(instance), owned: true})
+	}
+	return directories, nil
 }
 
-func (r *NMStateReconciler) applyNetworkPolicies(ctx context.Context, instance *nmstatev1.NMState) error {
+func (r *NMStateReconciler) networkPoliciesRenderData() render.RenderData {
 	data := render.MakeRenderData()
 	data.Data["HandlerNamespace"] = environment.GetEnvVar("HANDLER_NAMESPACE", "")
 	data.Data["OperatorNamespace"] = environment.GetEnvVar("OPERATOR_NAMESPACE", "")
 	data.Data["PluginNamespace"] = environment.GetEnvVar("HANDLER_NAMESPACE", "")
 	data.Data["MonitoringNamespace"] = environment.GetEnvVar("MONITORING_NAMESPACE", "")
 	data.Data["IsOpenShift"] = r.IsOpenShift
-
-	return r.renderAndApply(ctx, instance, data, "netpol", true)
+	return data
 }
 
-func (r *NMStateReconciler) applyRBAC(ctx context.Context, instance *nmstatev1.NMState) error {
+func (r *NMStateReconciler) rbacRenderData(ctx context.Context) (render.RenderData, error) {
 	data := render.MakeRenderData()
 	data.Data["HandlerNamespace"] = environment.GetEnvVar("HANDLER_NAMESPACE", "")
 	data.Data["HandlerImage"] = environment.GetEnvVar("RELATED_IMAGE_HANDLER_IMAGE", "")
@@ -265,16 +532,15 @@ func (r *NMStateReconciler) applyRBAC(ctx context.Context, instance *nmstatev1.N
 	data.Data["HandlerPrefix"] = environment.GetEnvVar("HANDLER_PREFIX", "")
 
 	if err := setClusterReaderExist(ctx, r.Client, data); err != nil {
-		return errors.Wrap(err, "failed checking if cluster-reader ClusterRole exists")
+		return data, errors.Wrap(err, "failed checking if cluster-reader ClusterRole exists")
 	}
 
 	data.Data["IsOpenShift"] = r.IsOpenShift
-
-	return r.renderAndApply(ctx, instance, data, "rbac", true)
+	return data, nil
 }
 
 // nolint: funlen
-func (r *NMStateReconciler) applyHandler(ctx context.Context, instance *nmstatev1.NMState) error {
+func (r *NMStateReconciler) handlerRenderData(ctx context.Context, instance *nmstatev1.NMState) (render.RenderData, error) {
 	data := render.MakeRenderData()
 	// Register ToYaml template method
 	data.Funcs["toYaml"] = render.ToYaml
@@ -356,7 +622,7 @@ func (r *NMStateReconciler) applyHandler(ctx context.Context, instance *nmstatev
 
 	webhookReplicaCountMin, webhookReplicaCountDesired, err := r.webhookReplicaCount(ctx, infraNodeSelector, infraTolerations)
 	if err != nil {
-		return fmt.Errorf("could not get min replica count for webhook: %w", err)
+		return data, fmt.Errorf("could not get min replica count for webhook: %w", err)
 	}
 
 	selfSignConfiguration := instance.Spec.SelfSignConfiguration
@@ -377,12 +643,104 @@ func (r *NMStateReconciler) applyHandler(ctx context.Context, instance *nmstatev
 			BindAddress: ":8089",
 		}
 	}
+	handlerMetrics, err := newHandlerMetricsData(instance.Spec.HandlerMetrics)
+	if err != nil {
+		return data, err
+	}
+
+	interfaceFilterJSON := ""
+	if instance.Spec.InterfaceFilter != nil {
+		if err = state.ValidateInterfaceFilter(instance.Spec.InterfaceFilter); err != nil {
+			return data, fmt.Errorf("invalid interface filter: %w", err)
+		}
+		rawInterfaceFilter, err := json.Marshal(instance.Spec.InterfaceFilter)
+		if err != nil {
+			return data, fmt.Errorf("failed serializing interface filter: %w", err)
+		}
+		interfaceFilterJSON = string(rawInterfaceFilter)
+	}
+
+	interfaceMetricsJSON := ""
+	if instance.Spec.InterfaceMetrics != nil {
+		if err = state.ValidateInterfaceMetrics(instance.Spec.InterfaceMetrics); err != nil {
+			return data, fmt.Errorf("invalid interface metrics: %w", err)
+		}
+		rawInterfaceMetrics, err := json.Marshal(instance.Spec.InterfaceMetrics)
+		if err != nil {
+			return data, fmt.Errorf("failed serializing interface metrics: %w", err)
+		}
+		interfaceMetricsJSON = string(rawInterfaceMetrics)
+	}
+
+	// Tracing is disabled at the handler if it is not configured
+	tracingJSON := ""
+	if instance.Spec.Tracing != nil && instance.Spec.Tracing.Endpoint != "" {
+		rawTracing, err := json.Marshal(instance.Spec.Tracing)
+		if err != nil {
+			return data, fmt.Errorf("failed serializing tracing: %w", err)
+		}
+		tracingJSON = string(rawTracing)
+	}
+
+	nodeNetworkStateLayout := instance.Spec.NodeNetworkStateLayout
+	if nodeNetworkStateLayout == "" {
+		nodeNetworkStateLayout = shared.NodeNetworkStateLayoutAggregate
+	}
+
+	// The history is disabled with a zero size
+	nodeNetworkStateHistorySize := int32(0)
+	nodeNetworkStateHistoryTTLSeconds := int64(0)
+	if history := instance.Spec.NodeNetworkStateHistory; history != nil {
+		nodeNetworkStateHistorySize = history.Size
+		if nodeNetworkStateHistorySize <= 0 {
+			nodeNetworkStateHistorySize = defaultNodeNetworkStateHistorySize
+		}
+		if history.TTL != nil {
+			nodeNetworkStateHistoryTTLSeconds = int64(history.TTL.Seconds())
+		}
+	}
 
-	logLevelHandlerCommandArg := ""
-	handlerReadinessProbeExtraArg := ""
-	if instance.Spec.LogLevel == shared.LogLevelDebug {
-		logLevelHandlerCommandArg = "debug"
-		handlerReadinessProbeExtraArg = "-vv"
+	// The transcripts are disabled with a zero size
+	enactmentTranscriptsSize := int32(0)
+	enactmentTranscriptsMaxBytes := int32(0)
+	enactmentTranscriptsTTLSeconds := int64(0)
+	if transcripts := instance.Spec.EnactmentTranscripts; transcripts != nil {
+		enactmentTranscriptsSize = transcripts.Size
+		if enactmentTranscriptsSize <= 0 {
+			enactmentTranscriptsSize = defaultEnactmentTranscriptsSize
+		}
+		enactmentTranscriptsMaxBytes = transcripts.MaxBytes
+		if transcripts.TTL != nil {
+			enactmentTranscriptsTTLSeconds = int64(transcripts.TTL.Seconds())
+		}
+	}
+
+	components := instance.Spec.Components
+	if components == nil {
+		components = &shared.Components{}
+	}
+	availability := instance.Spec.Availability
+	if availability == nil {
+		availability = &shared.Availability{}
+	}
+
+	logLevelHandlerCommandArg, handlerReadinessProbeExtraArg := handlerLogLevelArgs(instance.Spec.LogLevel)
+
+	handlerDaemonSets, err := handlerPoolDaemonSets(instance, handlerDaemonSetData{
+		NodeSelector:                  nodeSelector,
+		Tolerations:                   handlerTolerations,
+		Affinity:                      handlerAffinity,
+		LogLevelHandlerCommandArg:     logLevelHandlerCommandArg,
+		HandlerReadinessProbeExtraArg: handlerReadinessProbeExtraArg,
+		ProbeConfiguration:            probeConfig,
+		NNCPMaxRetries:                environment.GetEnvVarAsInt("NNCP_MAX_RETRIES", defaultNNCPMaxRetries),
+		NNCPInitialBackoffSeconds: int64(environment.GetEnvVarAsDuration(
+			"NNCP_INITIAL_BACKOFF_SECONDS", defaultNNCPInitialBackoff).Seconds()),
+		NNCPMaxBackoffSeconds: int64(environment.GetEnvVarAsDuration(
+			"NNCP_MAX_BACKOFF_SECONDS", defaultNNCPMaxBackoff).Seconds()),
+	})
+	if err != nil {
+		return data, err
 	}
 
 	data.Data["HandlerNamespace"] = environment.GetEnvVar("HANDLER_NAMESPACE", "")
@@ -394,19 +752,32 @@ func (r *NMStateReconciler) applyHandler(ctx context.Context, instance *nmstatev
 	data.Data["InfraTolerations"] = infraTolerations
 	data.Data["WebhookAffinity"] = infraAffinity
 	data.Data["WebhookReplicas"] = webhookReplicaCountDesired
-	data.Data["WebhookMinReplicas"] = webhookReplicaCountMin
-	data.Data["HandlerNodeSelector"] = nodeSelector
-	data.Data["HandlerTolerations"] = handlerTolerations
-	data.Data["HandlerAffinity"] = handlerAffinity
+	data.Data["WebhookAvailability"] = newAvailabilityData(availability.Webhook, webhookReplicaCountMin,
+		map[string]string{componentLabelKey: componentLabelPrefix + "webhook"})
+	data.Data["MetricsAvailability"] = newAvailabilityData(availability.Metrics, 0,
+		map[string]string{componentLabelKey: componentLabelPrefix + "metrics"})
 	data.Data["SelfSignConfiguration"] = selfSignConfiguration
-	data.Data["ProbeConfiguration"] = probeConfig
 	data.Data["MetricsConfiguration"] = metricsConfig
-	data.Data["LogLevelHandlerCommandArg"] = logLevelHandlerCommandArg
-	data.Data["HandlerReadinessProbeExtraArg"] = handlerReadinessProbeExtraArg
+	data.Data["HandlerMetrics"] = handlerMetrics
 	data.Data["IsOpenShift"] = r.IsOpenShift
-	data.Data["NNCPMaxRetries"] = environment.GetEnvVar("NNCP_MAX_RETRIES", "5")
-	data.Data["NNCPMaxBackoffSeconds"] = environment.GetEnvVar("NNCP_MAX_BACKOFF_SECONDS", "30")
-	data.Data["NNCPInitialBackoffSeconds"] = environment.GetEnvVar("NNCP_INITIAL_BACKOFF_SECONDS", "1")
+	data.Data["InterfaceFilterJSON"] = interfaceFilterJSON
+	data.Data["RedactPaths"] = strings.Join(instance.Spec.RedactPaths, ",")
+	data.Data["InterfaceMetricsJSON"] = interfaceMetricsJSON
+	data.Data["TracingJSON"] = tracingJSON
+	data.Data["Monitoring"] = newMonitoringData(instance.Spec.Monitoring)
+	data.Data["NodeNetworkStateLayout"] = nodeNetworkStateLayout
+	data.Data["NodeNetworkStateHistorySize"] = nodeNetworkStateHistorySize
+	data.Data["NodeNetworkStateHistoryTTLSeconds"] = nodeNetworkStateHistoryTTLSeconds
+	data.Data["EnactmentTranscriptsSize"] = enactmentTranscriptsSize
+	data.Data["EnactmentTranscriptsMaxBytes"] = enactmentTranscriptsMaxBytes
+	data.Data["EnactmentTranscriptsTTLSeconds"] = enactmentTranscriptsTTLSeconds
+	data.Data["HandlerDaemonSets"] = handlerDaemonSets
+	data.Data["HandlerComponent"] = newComponentData(components.Handler, "system-node-critical",
+		resourceList("100m", "100Mi"), resourceList("500m", "1Gi"))
+	data.Data["WebhookComponent"] = newComponentData(components.Webhook, "system-cluster-critical",
+		resourceList("30m", "20Mi"), resourceList("500m", "1Gi"))
+	data.Data["MetricsComponent"] = newComponentData(components.Metrics, "system-cluster-critical",
+		resourceList("30m", "20Mi"), resourceList("500m", "1Gi"))
 
 	// On OpenShift, fetch and serialize the TLS profile so handler-deployed
 	// pods can read it from a ConfigMap instead of calling the API server.
@@ -415,40 +786,244 @@ func (r *NMStateReconciler) applyHandler(ctx context.Context, instance *nmstatev
 	if r.IsOpenShift {
 		tlsProfileSpec, err := nmstatetls.FetchAPIServerTLSProfile(ctx, r.APIClient)
 		if err != nil {
-			return fmt.Errorf("failed fetching TLS profile for ConfigMap: %w", err)
+			return data, fmt.Errorf("failed fetching TLS profile for ConfigMap: %w", err)
 		}
 		// Refuse to roll out a profile the components cannot honor; it
 		// would crash-loop the TLS-serving pods.
 		_, unsupported, err := nmstatetls.NewTLSConfigFromProfile(tlsProfileSpec)
 		if err != nil {
-			return fmt.Errorf("cluster TLS profile cannot be honored: %w", err)
+			return data, fmt.Errorf("cluster TLS profile cannot be honored: %w", err)
 		}
 		r.tlsProfileWarning = unsupported.Message()
 		tlsJSON, err := json.Marshal(tlsProfileSpec)
 		if err != nil {
-			return fmt.Errorf("failed serializing TLS profile: %w", err)
+			return data, fmt.Errorf("failed serializing TLS profile: %w", err)
 		}
 		data.Data["TLSProfileJSON"] = string(tlsJSON)
 		data.Data["TLSProfileHash"] = fmt.Sprintf("%x", sha256.Sum256(tlsJSON))
 	}
 
-	return r.renderAndApply(ctx, instance, data, "handler", true)
+	return data, nil
+}
+
+// handlerMetricsData is the handler metrics bind address and its node port
+type handlerMetricsData struct {
+	BindAddress string
+	Port        int32
+}
+
+// newHandlerMetricsData returns nil if the handler metrics are disabled
+func newHandlerMetricsData(handlerMetrics *shared.HandlerMetrics) (*handlerMetricsData, error) {
+	if handlerMetrics == nil {
+		return nil, nil
+	}
+	if handlerMetrics.Address != "" && net.ParseIP(handlerMetrics.Address) == nil {
+		return nil, fmt.Errorf("invalid handler metrics address %q", handlerMetrics.Address)
+	}
+	return &handlerMetricsData{
+		BindAddress: net.JoinHostPort(handlerMetrics.Address, strconv.Itoa(int(handlerMetrics.Port))),
+		Port:        handlerMetrics.Port,
+	}, nil
+}
+
+// deleteHandlerMetrics removes the handler metrics Service and ServiceMonitor
+// once they are disabled
+func (r *NMStateReconciler) deleteHandlerMetrics(ctx context.Context) error {
+	serviceMonitor := &unstructured.Unstructured{}
+	serviceMonitor.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "monitoring.coreos.com",
+		Version: "v1",
+		Kind:    "ServiceMonitor",
+	})
+	serviceMonitor.SetNamespace(environment.GetEnvVar("HANDLER_NAMESPACE", ""))
+	serviceMonitor.SetName(handlerPrefixed("nmstate-handler-metrics"))
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: environment.GetEnvVar("HANDLER_NAMESPACE", ""),
+			Name:      handlerPrefixed("nmstate-handler-metrics"),
+		},
+	}
+	for _, obj := range []client.Object{serviceMonitor, service} {
+		if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return fmt.Errorf("failed deleting disabled handler metrics %s: %w", obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+func handlerLogLevelArgs(logLevel shared.LogLevel) (commandArg, readinessProbeExtraArg string) {
+	if logLevel == shared.LogLevelDebug {
+		return "debug", "-vv"
+	}
+	return "", ""
+}
+
+// handlerPoolDaemonSets returns the handler DaemonSets to render, the default
+// one excludes the pools nodes so only one handler runs per node.
+func handlerPoolDaemonSets(instance *nmstatev1.NMState, defaults handlerDaemonSetData) ([]handlerDaemonSetData, error) {
+	if err := defaults.applyRetryPolicy(instance.Spec.ReconcileConfiguration); err != nil {
+		return nil, fmt.Errorf("invalid reconcile configuration: %w", err)
+	}
+
+	pools := instance.Spec.HandlerPools
+	if len(pools) == 0 {
+		return []handlerDaemonSetData{defaults}, nil
+	}
+
+	defaultDaemonSet := defaults
+	defaultDaemonSet.Affinity = excludeHandlerPoolNodes(defaults.Affinity)
+	daemonSets := []handlerDaemonSetData{defaultDaemonSet}
+	for _, pool := range pools {
+		daemonSet := defaults
+		daemonSet.NameSuffix = "-" + pool.Name
+		daemonSet.Pool = pool.Name
+		daemonSet.NodeSelector = map[string]string{names.HandlerPoolLabelKey: pool.Name}
+		for key, value := range pool.NodeSelector {
+			daemonSet.NodeSelector[key] = value
+		}
+		if pool.Tolerations != nil {
+			daemonSet.Tolerations = pool.Tolerations
+		}
+		if pool.Affinity != nil {
+			daemonSet.Affinity = pool.Affinity
+		}
+		if pool.LogLevel != "" {
+			daemonSet.LogLevelHandlerCommandArg, daemonSet.HandlerReadinessProbeExtraArg = handlerLogLevelArgs(pool.LogLevel)
+		}
+		if pool.ProbeConfiguration != nil {
+			daemonSet.ProbeConfiguration = *pool.ProbeConfiguration
+		}
+		if err := daemonSet.applyRetryPolicy(pool.ReconcileConfiguration); err != nil {
+			return nil, fmt.Errorf("invalid handler pool %q reconcile configuration: %w", pool.Name, err)
+		}
+		daemonSets = append(daemonSets, daemonSet)
+	}
+	return daemonSets, nil
+}
+
+// handlerPoolForNode returns the first pool selecting the node labels
+func handlerPoolForNode(pools []nmstatev1.NMStateHandlerPool, nodeLabels map[string]string) string {
+	for _, pool := range pools {
+		if labels.SelectorFromSet(pool.NodeSelector).Matches(labels.Set(nodeLabels)) {
+			return pool.Name
+		}
+	}
+	return ""
+}
+
+// labelHandlerPoolNodes sets the handler pool label of every node to the
+// pool it belongs to, the label has a single value so a node is never
+// selected by two handler DaemonSets.
+func (r *NMStateReconciler) labelHandlerPoolNodes(ctx context.Context, pools []nmstatev1.NMStateHandlerPool) error {
+	listOptions := []client.ListOption{}
+	if len(pools) == 0 {
+		// Only the nodes left at a removed pool have to be unlabeled
+		listOptions = append(listOptions, client.HasLabels{names.HandlerPoolLabelKey})
+	}
+	nodes := corev1.NodeList{}
+	if err := r.List(ctx, &nodes, listOptions...); err != nil {
+		return fmt.Errorf("failed listing nodes for handler pools: %w", err)
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		pool := handlerPoolForNode(pools, node.Labels)
+		currentPool, labeled := node.Labels[names.HandlerPoolLabelKey]
+		if currentPool == pool && (labeled || pool == "") {
+			continue
+		}
+		patch := client.MergeFrom(node.DeepCopy())
+		if pool == "" {
+			delete(node.Labels, names.HandlerPoolLabelKey)
+		} else {
+			node.Labels[names.HandlerPoolLabelKey] = pool
+		}
+		r.Log.Info("Moving node between handler pools", "node", node.Name, "from", currentPool, "to", pool)
+		if err := r.APIClient.Patch(ctx, node, patch); err != nil {
+			return fmt.Errorf("failed labeling node %s with handler pool %q: %w", node.Name, pool, err)
+		}
+	}
+	return nil
+}
+
+// excludeHandlerPoolNodes requires the nodes not to belong to a handler pool
+// at every node selector term of the affinity.
+func excludeHandlerPoolNodes(affinity *corev1.Affinity) *corev1.Affinity {
+	excluded := affinity.DeepCopy()
+	if excluded == nil {
+		excluded = &corev1.Affinity{}
+	}
+	if excluded.NodeAffinity == nil {
+		excluded.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	required := excluded.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil {
+		required = &corev1.NodeSelector{}
+		excluded.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = required
+	}
+	if len(required.NodeSelectorTerms) == 0 {
+		required.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions,
+			corev1.NodeSelectorRequirement{
+				Key:      names.HandlerPoolLabelKey,
+				Operator: corev1.NodeSelectorOpDoesNotExist,
+			})
+	}
+	return excluded
+}
+
+// deleteStaleHandlerPools removes the handler DaemonSets of the pools no
+// longer configured.
+func (r *NMStateReconciler) deleteStaleHandlerPools(ctx context.Context, pools []nmstatev1.NMStateHandlerPool) error {
+	daemonSets := appsv1.DaemonSetList{}
+	if err := r.List(ctx, &daemonSets,
+		client.InNamespace(environment.GetEnvVar("HANDLER_NAMESPACE", "")),
+		client.HasLabels{names.HandlerPoolLabelKey},
+	); err != nil {
+		return fmt.Errorf("failed listing handler pool daemonsets: %w", err)
+	}
+	for i := range daemonSets.Items {
+		daemonSet := &daemonSets.Items[i]
+		pool := daemonSet.Labels[names.HandlerPoolLabelKey]
+		if slices.ContainsFunc(pools, func(p nmstatev1.NMStateHandlerPool) bool { return p.Name == pool }) {
+			continue
+		}
+		if err := r.Delete(ctx, daemonSet); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed deleting stale handler pool %q daemonset: %w", pool, err)
+		}
+	}
+	return nil
+}
+
+var uiPluginDirectory = filepath.Join("openshift", "ui-plugin")
+
+func uiPluginManifestsExist() bool {
+	_, err := os.Stat(filepath.Join(names.ManifestDir, "kubernetes-nmstate", uiPluginDirectory))
+	return err == nil
 }
 
-func (r *NMStateReconciler) applyOpenshiftUIPlugin(ctx context.Context, instance *nmstatev1.NMState) error {
+func uiPluginRenderData(instance *nmstatev1.NMState) render.RenderData {
 	data := render.MakeRenderData()
 	data.Funcs["toYaml"] = render.ToYaml
 	data.Data["PluginNamespace"] = environment.GetEnvVar("HANDLER_NAMESPACE", "openshift-nmstate")
-	data.Data["PluginName"] = environment.GetEnvVar("PLUGIN_NAME", "nmstate-console-plugin")
+	pluginName := environment.GetEnvVar("PLUGIN_NAME", "nmstate-console-plugin")
+	data.Data["PluginName"] = pluginName
 	data.Data["PluginImage"] = environment.GetEnvVar("PLUGIN_IMAGE", "quay.io/nmstate/nmstate-console-plugin:release-1.0.0")
 	data.Data["PluginPort"] = environment.GetEnvVar("PLUGIN_PORT", "9443")
 
+	availability := instance.Spec.Availability
+	if availability == nil {
+		availability = &shared.Availability{}
+	}
+	data.Data["PluginAvailability"] = newAvailabilityData(availability.ConsolePlugin, 0,
+		map[string]string{"app": pluginName})
+
 	// if not set in the NMState CR, these entries are nil
 	data.Data["InfraNodeSelector"] = instance.Spec.InfraNodeSelector
 	data.Data["InfraTolerations"] = instance.Spec.InfraTolerations
 	data.Data["InfraAffinity"] = instance.Spec.InfraAffinity
-
-	return r.renderAndApply(ctx, instance, data, filepath.Join("openshift", "ui-plugin"), true)
+	return data
 }
 
 func (r *NMStateReconciler) patchOpenshiftConsolePlugin(ctx context.Context) error {
@@ -560,6 +1135,7 @@ func (r *NMStateReconciler) webhookReplicaCount(
 
 func (r *NMStateReconciler) reconcileStatus(ctx context.Context, instance *nmstatev1.NMState) error {
 	progressing := []string{}
+	components := []shared.ComponentStatus{}
 	for _, deploymentKey := range r.deployments {
 		deployment := &appsv1.Deployment{}
 		if err := r.Get(ctx, deploymentKey, deployment); err != nil {
@@ -568,6 +1144,7 @@ func (r *NMStateReconciler) reconcileStatus(ctx context.Context, instance *nmsta
 			}
 			return errors.Wrap(err, "failed to get deployment")
 		}
+		components = append(components, deploymentComponentStatus(deployment))
 		if deployment.Status.UnavailableReplicas > 0 {
 			progressing = append(progressing, fmt.Sprintf(
 				"Deployment %q is not available (awaiting %d nodes)",
@@ -589,6 +1166,7 @@ func (r *NMStateReconciler) reconcileStatus(ctx context.Context, instance *nmsta
 			}
 			return errors.Wrap(err, "failed to get daemonset")
 		}
+		components = append(components, daemonSetComponentStatus(daemonSet))
 		if daemonSet.Status.NumberUnavailable > 0 {
 			progressing = append(progressing, fmt.Sprintf(
 				"DaemonSet %q is not available (awaiting %d nodes)",
@@ -604,6 +1182,22 @@ func (r *NMStateReconciler) reconcileStatus(ctx context.Context, instance *nmsta
 		}
 	}
 
+	instance.Status.Components = components
+	nmstateVersions, networkManagerVersions, err := r.nodeVersions(ctx)
+	if err != nil {
+		return err
+	}
+	instance.Status.NmstateVersions = nmstateVersions
+	instance.Status.NetworkManagerVersions = networkManagerVersions
+	instance.Status.TLSProfileWarning = r.tlsProfileWarning
+
+	for _, rollout := range instance.Status.HandlerRollouts {
+		if rollout.State != shared.HandlerRolloutStateRolledOut && rollout.Message != "" {
+			progressing = append(progressing, fmt.Sprintf("DaemonSet %q rollout is %s: %s",
+				rollout.DaemonSet, rollout.State, rollout.Message))
+		}
+	}
+
 	if len(progressing) > 0 {
 		instance.Status.Conditions.Set(
 			shared.NmstateConditionProgressing,
@@ -655,11 +1249,20 @@ func (r *NMStateReconciler) reconcileStatus(ctx context.Context, instance *nmsta
 		}
 	}
 
+	return r.applyStatus(ctx, instance)
+}
+
+// applyStatus writes the NMState status with server-side apply and
+// ForceOwnership, to prevent field manager conflicts that make the NMState CR
+// unrecoverable.
+func (r *NMStateReconciler) applyStatus(ctx context.Context, instance *nmstatev1.NMState) error {
 	// Clear managed fields before applying server-side apply to status subresource
 	instance.SetManagedFields(nil)
 
 	//nolint:staticcheck // TODO: migrate to SubResource("status").Apply()
-	return r.Client.Status().Patch(ctx, instance, client.Apply, nmstateOperatorFieldOwner, client.ForceOwnership)
+	err := r.nmstateClient().Status().Patch(ctx, r.instanceObject(instance), client.Apply, nmstateOperatorFieldOwner, client.ForceOwnership)
+	r.syncInstance(instance)
+	return err
 }
 
 func (r *NMStateReconciler) setDegradedCondition(
@@ -687,11 +1290,7 @@ func (r *NMStateReconciler) setDegradedCondition(
 		message,
 	)
 
-	// Use SSA with ForceOwnership, consistent with reconcileStatus(), to
-	// prevent field manager conflicts that make the NMState CR unrecoverable.
-	instance.SetManagedFields(nil)
-	//nolint:staticcheck // TODO: migrate to SubResource("status").Apply()
-	if err := r.Client.Status().Patch(ctx, instance, client.Apply, nmstateOperatorFieldOwner, client.ForceOwnership); err != nil {
+	if err := r.applyStatus(ctx, instance); err != nil {
 		// Callers invoke this helper while already handling another error
 		// and ignore its return value, so log the failure here to keep it
 		// visible for troubleshooting.
@@ -701,37 +1300,23 @@ func (r *NMStateReconciler) setDegradedCondition(
 	return nil
 }
 
-func (r *NMStateReconciler) renderAndApply(
+// applyObjects applies the rendered objects, the owned ones get the NMState
+// controller reference.
+func (r *NMStateReconciler) applyObjects(
 	ctx context.Context,
 	instance *nmstatev1.NMState,
-	data render.RenderData,
-	sourceDirectory string,
+	objs []*unstructured.Unstructured,
 	setControllerReference bool,
 ) error {
-	var err error
-
-	sourceFullDirectory := filepath.Join(names.ManifestDir, "kubernetes-nmstate", sourceDirectory)
-	objs, err := render.RenderDir(sourceFullDirectory, &data)
-	if err != nil {
-		return errors.Wrapf(err, "failed to render kubernetes-nmstate %s", sourceDirectory)
-	}
-
-	// If no file found in directory - return error
-	if len(objs) == 0 {
-		return fmt.Errorf("no manifests rendered from %s", sourceFullDirectory)
-	}
-
 	for _, obj := range objs {
-		// RenderDir seems to add an extra null entry to the list. It appears to be because of the
-		// nested templates. This just makes sure we don't try to apply an empty obj.
-		if obj.GetName() == "" {
-			continue
-		}
 		if setControllerReference {
-			// Set the controller reference. When the CR is removed, it will remove the CRDs as well
-			err = controllerutil.SetControllerReference(instance, obj, r.Scheme)
-			if err != nil {
-				return errors.Wrap(err, "failed to set owner reference")
+			// Set the controller reference. When the CR is removed, it will remove the CRDs as well.
+			// The NMState is not at the remote clusters, the garbage collector would remove
+			// the objects there.
+			if !r.remote {
+				if err := controllerutil.SetControllerReference(instance, obj, r.Scheme); err != nil {
+					return errors.Wrap(err, "failed to set owner reference")
+				}
 			}
 			if obj.GetKind() == "Deployment" {
 				r.deployments = append(r.deployments, client.ObjectKeyFromObject(obj))
@@ -739,9 +1324,7 @@ func (r *NMStateReconciler) renderAndApply(
 				r.daemonSets = append(r.daemonSets, client.ObjectKeyFromObject(obj))
 			}
 		}
-		// Use server-side apply
-		//nolint:staticcheck // TODO: migrate to client.Client.Apply()
-		err := r.Patch(ctx, obj, client.Apply, nmstateOperatorFieldOwner, client.ForceOwnership)
+		err := r.apply(ctx, instance, obj)
 		if err != nil {
 			// If the CRD doesn't exist (e.g., ServiceMonitor), log a warning and continue
 			if meta.IsNoMatchError(err) {
@@ -754,6 +1337,66 @@ func (r *NMStateReconciler) renderAndApply(
 	return nil
 }
 
+func renderManifests(data render.RenderData, sourceDirectory string) ([]*unstructured.Unstructured, error) {
+	sourceFullDirectory := filepath.Join(names.ManifestDir, "kubernetes-nmstate", sourceDirectory)
+	objs, err := render.RenderDir(sourceFullDirectory, &data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to render kubernetes-nmstate %s", sourceDirectory)
+	}
+
+	// If no file found in directory - return error
+	if len(objs) == 0 {
+		return nil, fmt.Errorf("no manifests rendered from %s", sourceFullDirectory)
+	}
+
+	// RenderDir seems to add an extra null entry to the list. It appears to be because of the
+	// nested templates. This just makes sure we don't try to apply an empty obj.
+	objs = slices.DeleteFunc(objs, func(obj *unstructured.Unstructured) bool { return obj.GetName() == "" })
+	for _, obj := range objs {
+		if err := mergeContainersEnv(obj); err != nil {
+			return nil, errors.Wrapf(err, "failed merging %s %s env", obj.GetKind(), obj.GetName())
+		}
+	}
+	return objs, nil
+}
+
+// mergeContainersEnv keeps one env var per name at the Deployment and
+// DaemonSet containers, the component overrides are rendered after the
+// operator env vars so they replace them in place.
+func mergeContainersEnv(obj *unstructured.Unstructured) error {
+	if obj.GetKind() != "Deployment" && obj.GetKind() != "DaemonSet" {
+		return nil
+	}
+	containers, found, err := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+	if err != nil || !found {
+		return err
+	}
+	for i := range containers {
+		container, ok := containers[i].(map[string]any)
+		if !ok {
+			continue
+		}
+		env, ok := container["env"].([]any)
+		if !ok {
+			continue
+		}
+		merged := []any{}
+		indexByName := map[string]int{}
+		for _, envVar := range env {
+			envVarFields, _ := envVar.(map[string]any)
+			name, _, _ := unstructured.NestedString(envVarFields, "name")
+			if index, duplicated := indexByName[name]; duplicated {
+				merged[index] = envVar
+				continue
+			}
+			indexByName[name] = len(merged)
+			merged = append(merged, envVar)
+		}
+		container["env"] = merged
+	}
+	return unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers")
+}
+
 func setClusterReaderExist(ctx context.Context, c client.Client, data render.RenderData) error {
 	var clusterReader rbac.ClusterRole
 	key := types.NamespacedName{Name: "cluster-reader"}
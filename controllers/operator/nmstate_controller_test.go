@@ -23,22 +23,31 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	"github.com/nmstate/kubernetes-nmstate/api/names"
 	"github.com/nmstate/kubernetes-nmstate/api/shared"
@@ -172,6 +181,10 @@ var _ = Describe("NMState controller reconcile", func() {
 			s.AddKnownTypes(nmstatev1.GroupVersion,
 				&nmstatev1.NMState{},
 				&nmstatev1.NMStateList{},
+				&nmstatev1.RemoteNMState{},
+				&nmstatev1.RemoteNMStateList{},
+				&nmstatev1.NodeNetworkConfigurationPolicy{},
+				&nmstatev1.NodeNetworkConfigurationPolicyList{},
 			)
 			// Add v1beta1 types for server-side apply to work with CRDs
 			s.AddKnownTypes(nmstatev1beta1.GroupVersion,
@@ -192,7 +205,7 @@ var _ = Describe("NMState controller reconcile", func() {
 			objs = append(objs, createTestCRDs()...)
 			objs = append(objs, extraObjs...)
 
-			fakeClient := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).WithStatusSubresource(nmstateObj).Build()
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).WithStatusSubresource(nmstateObj, &nmstatev1.RemoteNMState{}).Build()
 			return &applyCapableFakeClient{Client: fakeClient}
 		}
 	)
@@ -495,6 +508,1339 @@ var _ = Describe("NMState controller reconcile", func() {
 		})
 	})
 
+	Context("when operator spec has an interface filter", func() {
+		var (
+			request ctrl.Request
+		)
+		BeforeEach(func() {
+			nmstate := newNMState()
+			nmstate.Spec.InterfaceFilter = &shared.InterfaceFilter{
+				ExcludeNames:      []string{"veth*"},
+				DropDynamicRoutes: true,
+			}
+
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			request.Name = existingNMStateName
+			result, err := reconciler.Reconcile(context.Background(), request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(ctrl.Result{}))
+		})
+		It("should add the interface filter to handler daemonset", func() {
+			ds := &appsv1.DaemonSet{}
+			err := cl.Get(context.Background(), handlerKey, ds)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(envVariableStringPresent(
+				"INTERFACE_FILTER", `{"excludeNames":["veth*"],"dropDynamicRoutes":true}`, ds.Spec.Template.Spec.Containers[0].Env,
+			)).To(BeTrue())
+		})
+		It("should add the interface filter to metrics deployment", func() {
+			deployment := &appsv1.Deployment{}
+			metricsKey := types.NamespacedName{Namespace: handlerNamespace, Name: handlerPrefix + "-nmstate-metrics"}
+			err := cl.Get(context.Background(), metricsKey, deployment)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(envVariableStringPresent(
+				"INTERFACE_FILTER", `{"excludeNames":["veth*"],"dropDynamicRoutes":true}`, deployment.Spec.Template.Spec.Containers[0].Env,
+			)).To(BeTrue())
+		})
+	})
+
+	Context("when operator spec has redact paths", func() {
+		It("should not set them at the handler daemonset without them", func() {
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			for _, env := range ds.Spec.Template.Spec.Containers[0].Env {
+				Expect(env.Name).ToNot(Equal("STATE_REDACT_PATHS"))
+			}
+		})
+		It("should pass them to the handler daemonset", func() {
+			nmstate := newNMState()
+			nmstate.Spec.RedactPaths = []string{"interfaces.#.my-plugin.token", `dns-resolver.config.key\.secret`}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			Expect(envVariableStringPresent("STATE_REDACT_PATHS",
+				`interfaces.#.my-plugin.token,dns-resolver.config.key\.secret`, ds.Spec.Template.Spec.Containers[0].Env)).To(BeTrue())
+		})
+	})
+
+	Context("when operator spec has a node network state layout", func() {
+		It("should default it to Aggregate at handler daemonset", func() {
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			Expect(envVariableStringPresent("NNS_LAYOUT", "Aggregate", ds.Spec.Template.Spec.Containers[0].Env)).To(BeTrue())
+		})
+		It("should pass it to handler daemonset", func() {
+			nmstate := newNMState()
+			nmstate.Spec.NodeNetworkStateLayout = shared.NodeNetworkStateLayoutPerInterface
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			Expect(envVariableStringPresent("NNS_LAYOUT", "PerInterface", ds.Spec.Template.Spec.Containers[0].Env)).To(BeTrue())
+		})
+	})
+
+	Context("when operator spec has handler metrics", func() {
+		var handlerMetricsKey = types.NamespacedName{Namespace: handlerNamespace, Name: handlerPrefix + "-nmstate-handler-metrics"}
+		It("should not expose the handler metrics by default", func() {
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			Expect(ds.Spec.Template.Labels).ToNot(HaveKey("prometheus.nmstate.io"))
+			for _, env := range ds.Spec.Template.Spec.Containers[0].Env {
+				Expect(env.Name).ToNot(Equal("METRICS_BIND_ADDRESS"))
+			}
+			Expect(ds.Spec.Template.Spec.Containers[0].Ports).To(BeEmpty())
+			err = cl.Get(context.Background(), handlerMetricsKey, &corev1.Service{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+		It("should expose them at the configured address and port with their own service", func() {
+			nmstate := newNMState()
+			nmstate.Spec.HandlerMetrics = &shared.HandlerMetrics{Address: "10.0.0.1", Port: 8090}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			Expect(ds.Spec.Template.Labels).ToNot(HaveKey("prometheus.nmstate.io"))
+			Expect(envVariableStringPresent("METRICS_BIND_ADDRESS", "10.0.0.1:8090", ds.Spec.Template.Spec.Containers[0].Env)).To(BeTrue())
+			Expect(ds.Spec.Template.Spec.Containers[0].Ports).To(ConsistOf(
+				corev1.ContainerPort{Name: "metrics", ContainerPort: 8090, Protocol: corev1.ProtocolTCP}))
+
+			service := &corev1.Service{}
+			Expect(cl.Get(context.Background(), handlerMetricsKey, service)).To(Succeed())
+			Expect(service.Spec.Selector).To(Equal(map[string]string{"component": "kubernetes-nmstate-handler"}))
+			Expect(service.Labels).ToNot(HaveKey("prometheus.nmstate.io"))
+		})
+		It("should remove the handler metrics service once they are disabled", func() {
+			cl = setupFakeClient(newNMState(), &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Namespace: handlerMetricsKey.Namespace,
+				Name:      handlerMetricsKey.Name,
+			}})
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			err = cl.Get(context.Background(), handlerMetricsKey, &corev1.Service{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+		It("should fail reconcile with an invalid address", func() {
+			nmstate := newNMState()
+			nmstate.Spec.HandlerMetrics = &shared.HandlerMetrics{Address: "node01", Port: 8090}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).To(MatchError(ContainSubstring(`invalid handler metrics address "node01"`)))
+		})
+	})
+
+	Context("when operator spec has tracing", func() {
+		It("should not configure it at handler daemonset by default", func() {
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			for _, env := range ds.Spec.Template.Spec.Containers[0].Env {
+				Expect(env.Name).ToNot(Equal("TRACING"))
+			}
+		})
+		It("should add it to handler daemonset", func() {
+			samplingPercentage := int32(10)
+			nmstate := newNMState()
+			nmstate.Spec.Tracing = &shared.Tracing{
+				Endpoint:           "otel-collector.observability.svc:4317",
+				Insecure:           true,
+				SamplingPercentage: &samplingPercentage,
+			}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			Expect(envVariableStringPresent(
+				"TRACING",
+				`{"endpoint":"otel-collector.observability.svc:4317","insecure":true,"samplingPercentage":10}`,
+				ds.Spec.Template.Spec.Containers[0].Env,
+			)).To(BeTrue())
+		})
+	})
+
+	Context("when the prometheus rules are rendered", func() {
+		getPrometheusRuleGroups := func() []any {
+			prometheusRule := &unstructured.Unstructured{}
+			prometheusRule.SetGroupVersionKind(schema.GroupVersionKind{
+				Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule",
+			})
+			ExpectWithOffset(1, cl.Get(context.Background(), types.NamespacedName{
+				Namespace: handlerNamespace, Name: "kubernetes-nmstate-prometheus-rules",
+			}, prometheusRule)).To(Succeed())
+			groups, _, err := unstructured.NestedSlice(prometheusRule.Object, "spec", "groups")
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			return groups
+		}
+		findAlert := func(groups []any, name string) map[string]any {
+			for _, group := range groups {
+				rules, _, _ := unstructured.NestedSlice(group.(map[string]any), "rules")
+				for _, rule := range rules {
+					if rule.(map[string]any)["alert"] == name {
+						return rule.(map[string]any)
+					}
+				}
+			}
+			return nil
+		}
+		It("should render the alerts with the default durations", func() {
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			groups := getPrometheusRuleGroups()
+			Expect(groups).To(HaveLen(2))
+			Expect(findAlert(groups, "NodeNetworkConfigurationPolicyDegraded")).To(HaveKeyWithValue("for", "600s"))
+			Expect(findAlert(groups, "NodeNetworkConfigurationEnactmentFailing")).To(HaveKeyWithValue("for", "600s"))
+			Expect(findAlert(groups, "NMStateHandlerNotReady")).To(HaveKeyWithValue("for", "600s"))
+			// The handler metrics are disabled by default
+			Expect(findAlert(groups, "NodeNetworkStateNotRefreshed")).To(BeNil())
+		})
+		It("should render the configured durations", func() {
+			nmstate := newNMState()
+			nmstate.Spec.Monitoring = &shared.Monitoring{
+				EnactmentFailingFor:        &metav1.Duration{Duration: 30 * time.Minute},
+				NodeNetworkStateStaleAfter: &metav1.Duration{Duration: 5 * time.Minute},
+			}
+			nmstate.Spec.HandlerMetrics = &shared.HandlerMetrics{Port: 8090}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			groups := getPrometheusRuleGroups()
+			Expect(findAlert(groups, "NodeNetworkConfigurationEnactmentFailing")).To(HaveKeyWithValue("for", "1800s"))
+			Expect(findAlert(groups, "NodeNetworkConfigurationPolicyDegraded")).To(HaveKeyWithValue("for", "600s"))
+			Expect(findAlert(groups, "NodeNetworkStateNotRefreshed")).To(HaveKeyWithValue("expr", SatisfyAll(
+				ContainSubstring(`time() - kubernetes_nmstate_state_refresh_timestamp_seconds{namespace="nmstate"} > 300`),
+				ContainSubstring(`or up{namespace="nmstate", job="handler-nmstate-handler-metrics"} == 0`),
+			)))
+		})
+		It("should alert on the handler pods of every handler pool", func() {
+			nmstate := newNMState()
+			nmstate.Spec.HandlerPools = []nmstatev1.NMStateHandlerPool{{
+				Name:         "edge",
+				NodeSelector: map[string]string{"node-role.kubernetes.io/edge": ""},
+			}}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cl.Get(context.Background(), types.NamespacedName{Namespace: handlerNamespace, Name: handlerKey.Name + "-edge"},
+				&appsv1.DaemonSet{})).To(Succeed())
+			groups := getPrometheusRuleGroups()
+			Expect(findAlert(groups, "NMStateHandlerNotReady")).To(HaveKeyWithValue("expr", SatisfyAll(
+				ContainSubstring(`sum by (daemonset) (kube_daemonset_status_desired_number_scheduled{namespace="nmstate", daemonset=~"handler-nmstate-handler(-.+)?"})`),
+				ContainSubstring(`sum by (daemonset) (kube_daemonset_status_number_ready{namespace="nmstate", daemonset=~"handler-nmstate-handler(-.+)?"})`),
+			)))
+		})
+		It("should keep only the recording rules if the alerts are disabled", func() {
+			nmstate := newNMState()
+			nmstate.Spec.Monitoring = &shared.Monitoring{DisableAlerts: true}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			groups := getPrometheusRuleGroups()
+			Expect(groups).To(HaveLen(1))
+			Expect(groups[0]).To(HaveKeyWithValue("name", "kubernetes-nmstate.rules"))
+		})
+	})
+
+	Context("when operator spec has a node network state history", func() {
+		It("should disable it at handler daemonset by default", func() {
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			Expect(envVariableStringPresent("NNS_HISTORY_SIZE", "0", ds.Spec.Template.Spec.Containers[0].Env)).To(BeTrue())
+			Expect(envVariableStringPresent("NNS_HISTORY_TTL_SECONDS", "0", ds.Spec.Template.Spec.Containers[0].Env)).To(BeTrue())
+		})
+		It("should pass it to handler daemonset with a default size", func() {
+			nmstate := newNMState()
+			nmstate.Spec.NodeNetworkStateHistory = &shared.NodeNetworkStateHistory{
+				TTL: &metav1.Duration{Duration: 24 * time.Hour},
+			}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			Expect(envVariableStringPresent("NNS_HISTORY_SIZE", "10", ds.Spec.Template.Spec.Containers[0].Env)).To(BeTrue())
+			Expect(envVariableStringPresent("NNS_HISTORY_TTL_SECONDS", "86400", ds.Spec.Template.Spec.Containers[0].Env)).To(BeTrue())
+		})
+	})
+
+	Context("when operator spec has enactment transcripts", func() {
+		It("should disable them at handler daemonset by default", func() {
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			Expect(envVariableStringPresent("NNCE_TRANSCRIPTS_SIZE", "0", ds.Spec.Template.Spec.Containers[0].Env)).To(BeTrue())
+		})
+		It("should pass them to handler daemonset with a default size", func() {
+			nmstate := newNMState()
+			nmstate.Spec.EnactmentTranscripts = &shared.EnactmentTranscripts{
+				MaxBytes: 4096,
+				TTL:      &metav1.Duration{Duration: time.Hour},
+			}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			Expect(envVariableStringPresent("NNCE_TRANSCRIPTS_SIZE", "3", ds.Spec.Template.Spec.Containers[0].Env)).To(BeTrue())
+			Expect(envVariableStringPresent("NNCE_TRANSCRIPTS_MAX_BYTES", "4096", ds.Spec.Template.Spec.Containers[0].Env)).To(BeTrue())
+			Expect(envVariableStringPresent("NNCE_TRANSCRIPTS_TTL_SECONDS", "3600", ds.Spec.Template.Spec.Containers[0].Env)).To(BeTrue())
+		})
+	})
+
+	Context("when operator spec has component overrides", func() {
+		It("should keep the operator defaults without them", func() {
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			Expect(ds.Spec.Template.Spec.PriorityClassName).To(Equal("system-node-critical"))
+			Expect(ds.Spec.Template.Spec.ImagePullSecrets).To(BeEmpty())
+			container := ds.Spec.Template.Spec.Containers[0]
+			Expect(container.Image).To(Equal(handlerImage))
+			Expect(string(container.ImagePullPolicy)).To(Equal(imagePullPolicy))
+			Expect(container.Resources.Requests.Memory().String()).To(Equal("100Mi"))
+			Expect(container.Resources.Limits.Cpu().String()).To(Equal("500m"))
+		})
+		It("should render them at the handler daemonset and the webhook and metrics deployments", func() {
+			nmstate := newNMState()
+			nmstate.Spec.Components = &shared.Components{
+				Handler: &shared.ComponentOverrides{
+					Resources: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("200Mi")},
+					},
+					PriorityClassName: "handler-priority",
+					Image:             "quay.io/other_image",
+					ImagePullPolicy:   corev1.PullIfNotPresent,
+					ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "pull-secret"}},
+					Env:               []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}},
+				},
+				Webhook: &shared.ComponentOverrides{
+					PriorityClassName: "webhook-priority",
+				},
+				Metrics: &shared.ComponentOverrides{
+					Env: []corev1.EnvVar{{Name: "GOMAXPROCS", Value: "2"}},
+				},
+			}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			Expect(ds.Spec.Template.Spec.PriorityClassName).To(Equal("handler-priority"))
+			Expect(ds.Spec.Template.Spec.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: "pull-secret"}))
+			container := ds.Spec.Template.Spec.Containers[0]
+			Expect(container.Image).To(Equal("quay.io/other_image"))
+			Expect(container.ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+			Expect(container.Resources.Requests.Memory().String()).To(Equal("200Mi"))
+			Expect(container.Resources.Limits).To(BeEmpty())
+			Expect(envVariableStringPresent("HTTP_PROXY", "http://proxy:3128", container.Env)).To(BeTrue())
+			Expect(envVariableStringPresent("NNCE_TRANSCRIPTS_SIZE", "0", container.Env)).To(BeTrue())
+
+			webhook := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), webhookKey, webhook)).To(Succeed())
+			Expect(webhook.Spec.Template.Spec.PriorityClassName).To(Equal("webhook-priority"))
+			Expect(webhook.Spec.Template.Spec.Containers[0].Image).To(Equal(handlerImage))
+			Expect(webhook.Spec.Template.Spec.Containers[0].Resources.Requests.Cpu().String()).To(Equal("30m"))
+
+			metrics := &appsv1.Deployment{}
+			metricsKey := types.NamespacedName{Namespace: handlerNamespace, Name: handlerPrefix + "-nmstate-metrics"}
+			Expect(cl.Get(context.Background(), metricsKey, metrics)).To(Succeed())
+			Expect(metrics.Spec.Template.Spec.PriorityClassName).To(Equal("system-cluster-critical"))
+			Expect(envVariableStringPresent("GOMAXPROCS", "2", metrics.Spec.Template.Spec.Containers[0].Env)).To(BeTrue())
+		})
+		It("should replace the operator env vars with the overridden ones", func() {
+			nmstate := newNMState()
+			nmstate.Spec.Components = &shared.Components{
+				Handler: &shared.ComponentOverrides{
+					Env: []corev1.EnvVar{{Name: "ENABLE_PROFILER", Value: "True"}},
+				},
+			}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			env := ds.Spec.Template.Spec.Containers[0].Env
+			profilerEnv := []corev1.EnvVar{}
+			for i, envVar := range env {
+				if envVar.Name == "ENABLE_PROFILER" {
+					profilerEnv = append(profilerEnv, envVar)
+					Expect(env[i+1].Name).To(Equal("PROFILER_PORT"), "the overridden env var should keep its position")
+				}
+			}
+			Expect(profilerEnv).To(ConsistOf(corev1.EnvVar{Name: "ENABLE_PROFILER", Value: "True"}))
+		})
+	})
+
+	Context("when operator spec has reconcile configuration", func() {
+		It("should default it at handler daemonset", func() {
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			env := ds.Spec.Template.Spec.Containers[0].Env
+			Expect(envVariableStringPresent("NNCP_MAX_RETRIES", "5", env)).To(BeTrue())
+			Expect(envVariableStringPresent("NNCP_INITIAL_BACKOFF_SECONDS", "1", env)).To(BeTrue())
+			Expect(envVariableStringPresent("NNCP_MAX_BACKOFF_SECONDS", "30", env)).To(BeTrue())
+		})
+		It("should pass it to handler daemonset", func() {
+			nmstate := newNMState()
+			nmstate.Spec.ReconcileConfiguration = &shared.RetryPolicy{
+				MaxRetries:     ptr.To[int32](3),
+				InitialBackoff: &metav1.Duration{Duration: 5 * time.Second},
+				MaxBackoff:     &metav1.Duration{Duration: 2 * time.Minute},
+			}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			env := ds.Spec.Template.Spec.Containers[0].Env
+			Expect(envVariableStringPresent("NNCP_MAX_RETRIES", "3", env)).To(BeTrue())
+			Expect(envVariableStringPresent("NNCP_INITIAL_BACKOFF_SECONDS", "5", env)).To(BeTrue())
+			Expect(envVariableStringPresent("NNCP_MAX_BACKOFF_SECONDS", "120", env)).To(BeTrue())
+		})
+		It("should fail reconcile with an initial backoff over the max backoff", func() {
+			nmstate := newNMState()
+			nmstate.Spec.ReconcileConfiguration = &shared.RetryPolicy{
+				InitialBackoff: &metav1.Duration{Duration: time.Minute},
+			}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).To(MatchError(ContainSubstring("initial backoff 60s exceeds max backoff 30s")))
+		})
+	})
+
+	Context("when operator spec has handler pools", func() {
+		var (
+			edgeKey = types.NamespacedName{Namespace: handlerNamespace, Name: handlerPrefix + "-nmstate-handler-edge"}
+			newNode = func(name string, nodeLabels map[string]string) *corev1.Node {
+				return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels}}
+			}
+			nodeLabels = func(name string) map[string]string {
+				node := &corev1.Node{}
+				ExpectWithOffset(1, cl.Get(context.Background(), types.NamespacedName{Name: name}, node)).To(Succeed())
+				return node.Labels
+			}
+		)
+		BeforeEach(func() {
+			nmstate := newNMState()
+			nmstate.Spec.LogLevel = shared.LogLevelInfo
+			nmstate.Spec.HandlerPools = []nmstatev1.NMStateHandlerPool{
+				{
+					Name:         "edge",
+					NodeSelector: map[string]string{"node-role.kubernetes.io/edge": ""},
+					Tolerations:  []corev1.Toleration{{Key: "edge", Operator: corev1.TolerationOpExists}},
+					LogLevel:     shared.LogLevelDebug,
+					ReconcileConfiguration: &shared.RetryPolicy{
+						MaxRetries: ptr.To[int32](10),
+					},
+				},
+				{
+					Name:         "far-edge",
+					NodeSelector: map[string]string{"zone": "far"},
+				},
+			}
+			cl = setupFakeClient(nmstate,
+				newNode("core", map[string]string{"zone": "near"}),
+				newNode("edge", map[string]string{"node-role.kubernetes.io/edge": ""}),
+				newNode("edge-far", map[string]string{"node-role.kubernetes.io/edge": "", "zone": "far"}),
+				newNode("far", map[string]string{"zone": "far"}),
+				newNode("moved", map[string]string{names.HandlerPoolLabelKey: "removed"}),
+				&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+					Namespace: handlerNamespace,
+					Name:      handlerPrefix + "-nmstate-handler-removed",
+					Labels:    map[string]string{names.HandlerPoolLabelKey: "removed"},
+				}},
+			)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("should label every node with the first pool selecting it", func() {
+			Expect(nodeLabels("core")).ToNot(HaveKey(names.HandlerPoolLabelKey))
+			Expect(nodeLabels("edge")).To(HaveKeyWithValue(names.HandlerPoolLabelKey, "edge"))
+			Expect(nodeLabels("edge-far")).To(HaveKeyWithValue(names.HandlerPoolLabelKey, "edge"))
+			Expect(nodeLabels("far")).To(HaveKeyWithValue(names.HandlerPoolLabelKey, "far-edge"))
+			Expect(nodeLabels("moved")).ToNot(HaveKey(names.HandlerPoolLabelKey))
+		})
+		It("should render a handler daemonset per pool with its tuning", func() {
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), edgeKey, ds)).To(Succeed())
+			Expect(ds.Labels).To(HaveKeyWithValue(names.HandlerPoolLabelKey, "edge"))
+			Expect(ds.Spec.Selector.MatchLabels).To(HaveKeyWithValue("name", handlerPrefix+"-nmstate-handler-edge"))
+			podSpec := ds.Spec.Template.Spec
+			Expect(podSpec.NodeSelector).To(Equal(map[string]string{
+				names.HandlerPoolLabelKey:      "edge",
+				"node-role.kubernetes.io/edge": "",
+			}))
+			Expect(podSpec.Tolerations).To(ConsistOf(corev1.Toleration{Key: "edge", Operator: corev1.TolerationOpExists}))
+			Expect(podSpec.Containers[0].Args).To(ContainElement("debug"))
+			Expect(envVariableStringPresent("NNCP_MAX_RETRIES", "10", podSpec.Containers[0].Env)).To(BeTrue())
+
+			farEdgeKey := types.NamespacedName{Namespace: handlerNamespace, Name: handlerPrefix + "-nmstate-handler-far-edge"}
+			Expect(cl.Get(context.Background(), farEdgeKey, ds)).To(Succeed())
+			Expect(ds.Spec.Template.Spec.Containers[0].Args).ToNot(ContainElement("debug"))
+			Expect(envVariableStringPresent("NNCP_MAX_RETRIES", "5", ds.Spec.Template.Spec.Containers[0].Env)).To(BeTrue())
+		})
+		It("should exclude the pools nodes from the default handler daemonset", func() {
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			nodeAffinity := ds.Spec.Template.Spec.Affinity.NodeAffinity
+			Expect(nodeAffinity).ToNot(BeNil())
+			Expect(nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(ConsistOf(
+				corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      names.HandlerPoolLabelKey,
+					Operator: corev1.NodeSelectorOpDoesNotExist,
+				}}},
+			))
+		})
+		It("should remove the daemonsets of the removed pools", func() {
+			staleKey := types.NamespacedName{Namespace: handlerNamespace, Name: handlerPrefix + "-nmstate-handler-removed"}
+			Expect(apierrors.IsNotFound(cl.Get(context.Background(), staleKey, &appsv1.DaemonSet{}))).To(BeTrue())
+			Expect(cl.Get(context.Background(), handlerKey, &appsv1.DaemonSet{})).To(Succeed())
+		})
+		It("should unlabel the nodes once every pool is removed", func() {
+			cl = setupFakeClient(newNMState(),
+				newNode("core", map[string]string{"zone": "near"}),
+				newNode("edge", map[string]string{names.HandlerPoolLabelKey: "edge"}),
+			)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nodeLabels("edge")).ToNot(HaveKey(names.HandlerPoolLabelKey))
+			Expect(nodeLabels("core")).To(HaveKeyWithValue("zone", "near"))
+		})
+	})
+
+	Context("when operator spec has manifest overlays", func() {
+		var (
+			overlaysConfigMap = func(data map[string]string) *corev1.ConfigMap {
+				return &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: operatorNamespace, Name: "nmstate-overlays"},
+					Data:       data,
+				}
+			}
+			reconcileWith = func(configMap *corev1.ConfigMap) error {
+				nmstate := newNMState()
+				nmstate.Spec.ManifestOverlays = &corev1.LocalObjectReference{Name: "nmstate-overlays"}
+				cl = setupFakeClient(nmstate, configMap)
+				reconciler.Client = cl
+				reconciler.APIClient = cl
+				_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+					NamespacedName: types.NamespacedName{Name: existingNMStateName},
+				})
+				return err
+			}
+			overlaysCondition = func() *shared.Condition {
+				nmstate := &nmstatev1.NMState{}
+				Expect(cl.Get(context.Background(), types.NamespacedName{Name: existingNMStateName}, nmstate)).To(Succeed())
+				return nmstate.Status.Conditions.Find(shared.NmstateConditionManifestOverlaysApplied)
+			}
+		)
+		It("should apply strategic merge and JSON patches to the rendered manifests", func() {
+			err := reconcileWith(overlaysConfigMap(map[string]string{
+				"webhook-priority": `
+patch: |
+  apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: ` + webhookKey.Name + `
+    namespace: ` + webhookKey.Namespace + `
+  spec:
+    template:
+      spec:
+        priorityClassName: webhook-priority
+`,
+				"handler-label": `
+target:
+  kind: DaemonSet
+  name: ` + handlerKey.Name + `
+patch: |
+  - op: add
+    path: /metadata/labels/team
+    value: network
+`,
+			}))
+			Expect(err).ToNot(HaveOccurred())
+
+			webhook := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), webhookKey, webhook)).To(Succeed())
+			Expect(webhook.Spec.Template.Spec.PriorityClassName).To(Equal("webhook-priority"))
+			Expect(webhook.Spec.Template.Spec.Containers).ToNot(BeEmpty())
+
+			ds := &appsv1.DaemonSet{}
+			Expect(cl.Get(context.Background(), handlerKey, ds)).To(Succeed())
+			Expect(ds.Labels).To(HaveKeyWithValue("team", "network"))
+
+			condition := overlaysCondition()
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(shared.NmstateManifestOverlaysApplied))
+		})
+		It("should report the overlays that match no manifest", func() {
+			err := reconcileWith(overlaysConfigMap(map[string]string{
+				"missing": `
+target:
+  kind: Deployment
+  name: missing
+patch: |
+  - op: add
+    path: /metadata/labels/team
+    value: network
+`,
+			}))
+			Expect(err).ToNot(HaveOccurred())
+
+			condition := overlaysCondition()
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(shared.NmstateManifestOverlayUnmatched))
+			Expect(condition.Message).To(ContainSubstring("missing"))
+		})
+		It("should not apply the manifests with an invalid overlay", func() {
+			err := reconcileWith(overlaysConfigMap(map[string]string{
+				"no-target": `
+patch: |
+  - op: remove
+    path: /spec/template/spec/tolerations
+`,
+			}))
+			Expect(err).To(MatchError(ContainSubstring("a JSON patch needs a target")))
+
+			Expect(cl.Get(context.Background(), handlerKey, &appsv1.DaemonSet{})).ToNot(Succeed())
+			condition := overlaysCondition()
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(shared.NmstateInvalidManifestOverlay))
+		})
+		It("should not apply the manifests when a patch fails", func() {
+			err := reconcileWith(overlaysConfigMap(map[string]string{
+				"bad-path": `
+target:
+  kind: DaemonSet
+  name: ` + handlerKey.Name + `
+patch: |
+  - op: replace
+    path: /spec/missing/field
+    value: foo
+`,
+			}))
+			Expect(err).To(HaveOccurred())
+
+			Expect(cl.Get(context.Background(), handlerKey, &appsv1.DaemonSet{})).ToNot(Succeed())
+			// The rbac manifests are applied before the handler ones
+			Expect(cl.Get(context.Background(), types.NamespacedName{Namespace: handlerNamespace, Name: handlerPrefix + "-nmstate-handler"},
+				&corev1.ServiceAccount{})).ToNot(Succeed())
+			nmstate := &nmstatev1.NMState{}
+			Expect(cl.Get(context.Background(), types.NamespacedName{Name: existingNMStateName}, nmstate)).To(Succeed())
+			degraded := nmstate.Status.Conditions.Find(shared.NmstateConditionDegraded)
+			Expect(degraded).ToNot(BeNil())
+			Expect(degraded.Reason).To(Equal(shared.NmstateInvalidManifestOverlay))
+			Expect(overlaysCondition().Reason).To(Equal(shared.NmstateInvalidManifestOverlay))
+		})
+		It("should fail reconcile when the configmap is missing", func() {
+			nmstate := newNMState()
+			nmstate.Spec.ManifestOverlays = &corev1.LocalObjectReference{Name: "nmstate-overlays"}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).To(MatchError(ContainSubstring("failed getting manifest overlays configmap")))
+			Expect(overlaysCondition().Status).To(Equal(corev1.ConditionFalse))
+		})
+	})
+
+	Context("when operator spec has availability", func() {
+		var (
+			metricsKey = types.NamespacedName{Namespace: handlerNamespace, Name: handlerPrefix + "-nmstate-metrics"}
+			reconcile  = func() {
+				_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+					NamespacedName: types.NamespacedName{Name: existingNMStateName},
+				})
+				Expect(err).ToNot(HaveOccurred())
+			}
+			hostnameSpread = func(component string) corev1.TopologySpreadConstraint {
+				return corev1.TopologySpreadConstraint{
+					MaxSkew:           1,
+					TopologyKey:       corev1.LabelHostname,
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"component": "kubernetes-nmstate-" + component},
+					},
+				}
+			}
+		)
+		It("should default the budgets and spread the pods across the nodes", func() {
+			reconcile()
+
+			metricsPDB := &policyv1.PodDisruptionBudget{}
+			Expect(cl.Get(context.Background(), metricsKey, metricsPDB)).To(Succeed())
+			Expect(metricsPDB.Spec.MinAvailable.IntValue()).To(Equal(0))
+			Expect(metricsPDB.Spec.MaxUnavailable).To(BeNil())
+			Expect(metricsPDB.Spec.Selector.MatchLabels).To(HaveKeyWithValue("name", metricsKey.Name))
+
+			webhook := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), webhookKey, webhook)).To(Succeed())
+			Expect(webhook.Spec.Template.Spec.TopologySpreadConstraints).To(ConsistOf(hostnameSpread("webhook")))
+
+			metrics := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), metricsKey, metrics)).To(Succeed())
+			Expect(metrics.Spec.Template.Spec.TopologySpreadConstraints).To(ConsistOf(hostnameSpread("metrics")))
+		})
+		It("should render the configured budgets and topology spread constraints", func() {
+			nmstate := newNMState()
+			nmstate.Spec.Availability = &shared.Availability{
+				Webhook: &shared.DeploymentAvailability{
+					PodDisruptionBudget: &shared.PodDisruptionBudgetConfiguration{
+						MaxUnavailable: ptr.To(intstr.FromString("50%")),
+					},
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+						MaxSkew:           1,
+						TopologyKey:       corev1.LabelTopologyZone,
+						WhenUnsatisfiable: corev1.ScheduleAnyway,
+					}},
+				},
+				Metrics: &shared.DeploymentAvailability{
+					PodDisruptionBudget: &shared.PodDisruptionBudgetConfiguration{
+						MinAvailable: ptr.To(intstr.FromInt32(1)),
+					},
+				},
+			}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			reconcile()
+
+			webhookPDB := &policyv1.PodDisruptionBudget{}
+			Expect(cl.Get(context.Background(), webhookKey, webhookPDB)).To(Succeed())
+			Expect(webhookPDB.Spec.MinAvailable).To(BeNil())
+			Expect(webhookPDB.Spec.MaxUnavailable.String()).To(Equal("50%"))
+
+			webhook := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), webhookKey, webhook)).To(Succeed())
+			Expect(webhook.Spec.Template.Spec.TopologySpreadConstraints).To(ConsistOf(corev1.TopologySpreadConstraint{
+				MaxSkew:           1,
+				TopologyKey:       corev1.LabelTopologyZone,
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"component": "kubernetes-nmstate-webhook"},
+				},
+			}))
+
+			metricsPDB := &policyv1.PodDisruptionBudget{}
+			Expect(cl.Get(context.Background(), metricsKey, metricsPDB)).To(Succeed())
+			Expect(metricsPDB.Spec.MinAvailable.IntValue()).To(Equal(1))
+
+			metrics := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), metricsKey, metrics)).To(Succeed())
+			Expect(metrics.Spec.Template.Spec.TopologySpreadConstraints).To(ConsistOf(hostnameSpread("metrics")))
+		})
+		It("should render the console plugin budget and topology spread constraints", func() {
+			nmstate := newNMState()
+			nmstate.Spec.Availability = &shared.Availability{
+				ConsolePlugin: &shared.DeploymentAvailability{
+					PodDisruptionBudget: &shared.PodDisruptionBudgetConfiguration{
+						MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+					},
+				},
+			}
+			objs, err := renderManifests(uiPluginRenderData(nmstate), uiPluginDirectory)
+			Expect(err).ToNot(HaveOccurred())
+
+			pdbs := renderedOfKind(objs, "PodDisruptionBudget")
+			Expect(pdbs).To(HaveLen(1))
+			maxUnavailable, _, err := unstructured.NestedFieldNoCopy(pdbs[0].(*unstructured.Unstructured).Object, "spec", "maxUnavailable")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(maxUnavailable).To(BeEquivalentTo(1))
+
+			deployments := renderedOfKind(objs, "Deployment")
+			Expect(deployments).To(HaveLen(1))
+			constraints, _, err := unstructured.NestedSlice(deployments[0].(*unstructured.Unstructured).Object,
+				"spec", "template", "spec", "topologySpreadConstraints")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(constraints).To(ConsistOf(HaveKeyWithValue("labelSelector",
+				HaveKeyWithValue("matchLabels", HaveKeyWithValue("app", "nmstate-console-plugin")))))
+		})
+		It("should reconcile the node taint changes, they change the infra nodes count", func() {
+			node := dummyNode("node1", nil, nil)
+			tainted := node.DeepCopy()
+			tainted.Spec.Taints = []corev1.Taint{{Key: "node-role.kubernetes.io/infra", Effect: corev1.TaintEffectNoSchedule}}
+			annotated := node.DeepCopy()
+			annotated.Annotations = map[string]string{"foo": "bar"}
+
+			taintsChanged := nodeTaintsChangedPredicate()
+			Expect(taintsChanged.Update(event.TypedUpdateEvent[*corev1.Node]{ObjectOld: node, ObjectNew: tainted})).To(BeTrue())
+			Expect(taintsChanged.Update(event.TypedUpdateEvent[*corev1.Node]{ObjectOld: node, ObjectNew: annotated})).To(BeFalse())
+		})
+	})
+
+	Context("when NMState is uninstalled", func() {
+		var (
+			recorder       *record.FakeRecorder
+			deletedNMState = func(uninstall *shared.UninstallConfiguration) *nmstatev1.NMState {
+				nmstate := newNMState()
+				nmstate.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				nmstate.Finalizers = []string{names.NMStateUninstallFinalizer}
+				nmstate.Spec.Uninstall = uninstall
+				return nmstate
+			}
+			progressingPolicy = &nmstatev1.NodeNetworkConfigurationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "bond0"},
+				Status: shared.NodeNetworkConfigurationPolicyStatus{
+					Conditions: shared.ConditionList{{
+						Type:   shared.NodeNetworkConfigurationPolicyConditionProgressing,
+						Status: corev1.ConditionTrue,
+					}},
+				},
+			}
+			deployed = func() []runtime.Object {
+				return []runtime.Object{
+					progressingPolicy,
+					&nmstatev1beta1.NodeNetworkState{ObjectMeta: metav1.ObjectMeta{Name: "node01"}},
+					&nmstatev1beta1.NodeNetworkConfigurationEnactment{ObjectMeta: metav1.ObjectMeta{Name: "node01.bond0"}},
+					&admissionregistrationv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: handlerPrefix + "-nmstate"}},
+					&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+						Namespace: handlerNamespace,
+						Name:      handlerKey.Name,
+						Labels:    map[string]string{"component": "kubernetes-nmstate-handler"},
+					}},
+					&networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{
+						Namespace: handlerNamespace,
+						Name:      "allow-cert-manager-egress-api-6443",
+					}},
+					&apiextv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "nodenetworkstates.nmstate.io"}},
+					&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+						Namespace: handlerNamespace,
+						Name:      "node01-nns-history",
+						Labels:    map[string]string{shared.NodeNetworkStateHistoryLabel: "true"},
+					}},
+					&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+						Name:   "edge01",
+						Labels: map[string]string{names.HandlerPoolLabelKey: "edge"},
+					}},
+				}
+			}
+			reconcile = func() (ctrl.Result, error) {
+				return reconciler.Reconcile(context.Background(), ctrl.Request{
+					NamespacedName: types.NamespacedName{Name: existingNMStateName},
+				})
+			}
+			exists = func(obj client.Object, key types.NamespacedName) bool {
+				err := cl.Get(context.Background(), key, obj)
+				if apierrors.IsNotFound(err) {
+					return false
+				}
+				Expect(err).ToNot(HaveOccurred())
+				return true
+			}
+		)
+		BeforeEach(func() {
+			recorder = record.NewFakeRecorder(10)
+			reconciler.Recorder = recorder
+		})
+		AfterEach(func() {
+			reconciler.Recorder = nil
+		})
+		It("should add the uninstall finalizer", func() {
+			_, err := reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists(&nmstatev1.NMState{}, types.NamespacedName{Name: existingNMStateName})).To(BeTrue())
+			nmstate := &nmstatev1.NMState{}
+			Expect(cl.Get(context.Background(), types.NamespacedName{Name: existingNMStateName}, nmstate)).To(Succeed())
+			Expect(nmstate.Finalizers).To(ContainElement(names.NMStateUninstallFinalizer))
+		})
+		It("should remove what it deployed, report it and release the NMState", func() {
+			cl = setupFakeClient(deletedNMState(nil), deployed()...)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			result, err := reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(ctrl.Result{}))
+
+			Expect(exists(&admissionregistrationv1.MutatingWebhookConfiguration{},
+				types.NamespacedName{Name: handlerPrefix + "-nmstate"})).To(BeFalse())
+			Expect(exists(&appsv1.DaemonSet{}, handlerKey)).To(BeFalse())
+			Expect(exists(&nmstatev1beta1.NodeNetworkState{}, types.NamespacedName{Name: "node01"})).To(BeFalse())
+			Expect(exists(&nmstatev1beta1.NodeNetworkConfigurationEnactment{}, types.NamespacedName{Name: "node01.bond0"})).To(BeFalse())
+			Expect(exists(&networkingv1.NetworkPolicy{},
+				types.NamespacedName{Namespace: handlerNamespace, Name: "allow-cert-manager-egress-api-6443"})).To(BeFalse())
+			Expect(exists(&apiextv1.CustomResourceDefinition{}, types.NamespacedName{Name: "nodenetworkstates.nmstate.io"})).To(BeTrue())
+			Expect(exists(&corev1.ConfigMap{}, types.NamespacedName{Namespace: handlerNamespace, Name: "node01-nns-history"})).To(BeFalse())
+			Expect(exists(&nmstatev1.NMState{}, types.NamespacedName{Name: existingNMStateName})).To(BeFalse())
+			edgeNode := &corev1.Node{}
+			Expect(cl.Get(context.Background(), types.NamespacedName{Name: "edge01"}, edgeNode)).To(Succeed())
+			Expect(edgeNode.Labels).ToNot(HaveKey(names.HandlerPoolLabelKey))
+
+			Expect(recorder.Events).To(Receive(SatisfyAll(
+				ContainSubstring(Uninstalled),
+				ContainSubstring("MutatingWebhookConfiguration handler-nmstate"),
+				ContainSubstring("DaemonSet nmstate/handler-nmstate-handler"),
+				ContainSubstring("1 NodeNetworkStates"),
+				ContainSubstring("1 NodeNetworkConfigurationEnactments"),
+				ContainSubstring("NetworkPolicy nmstate/allow-cert-manager-egress-api-6443"),
+				ContainSubstring("1 NodeNetworkState histories"),
+				ContainSubstring("1 node handler pool labels"),
+			)))
+		})
+		It("should remove the CRDs when requested", func() {
+			cl = setupFakeClient(deletedNMState(&shared.UninstallConfiguration{RemoveCRDs: true}), deployed()...)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists(&apiextv1.CustomResourceDefinition{}, types.NamespacedName{Name: "nodenetworkstates.nmstate.io"})).To(BeFalse())
+			Expect(exists(&corev1.ConfigMap{}, types.NamespacedName{Namespace: handlerNamespace, Name: "node01-nns-history"})).To(BeFalse())
+			Expect(exists(&nmstatev1.NMState{}, types.NamespacedName{Name: existingNMStateName})).To(BeFalse())
+		})
+		It("should wait for the progressing policies when requested", func() {
+			cl = setupFakeClient(deletedNMState(&shared.UninstallConfiguration{WaitForProgressingPolicies: true}), deployed()...)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			result, err := reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(ctrl.Result{RequeueAfter: uninstallRequeue}))
+			Expect(exists(&nmstatev1beta1.NodeNetworkState{}, types.NamespacedName{Name: "node01"})).To(BeTrue())
+			nmstate := &nmstatev1.NMState{}
+			Expect(cl.Get(context.Background(), types.NamespacedName{Name: existingNMStateName}, nmstate)).To(Succeed())
+			Expect(nmstate.Status.Uninstall).ToNot(BeNil())
+			Expect(nmstate.Status.Uninstall.Message).To(ContainSubstring("bond0"))
+		})
+	})
+
+	Context("when a RemoteNMState targets a remote cluster", func() {
+		const (
+			remoteNMStateName      = "guest-cluster"
+			remoteNMStateNamespace = "clusters-guest"
+		)
+		var (
+			remoteClient     client.Client
+			remoteNMStateKey = types.NamespacedName{Namespace: remoteNMStateNamespace, Name: remoteNMStateName}
+			remoteNMState    = func() *nmstatev1.RemoteNMState {
+				return &nmstatev1.RemoteNMState{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:         remoteNMStateNamespace,
+						Name:              remoteNMStateName,
+						CreationTimestamp: metav1.NewTime(time.Now().Add(time.Hour)),
+					},
+					Spec: nmstatev1.RemoteNMStateSpec{
+						RemoteCluster: shared.RemoteCluster{
+							KubeconfigSecret: shared.KubeconfigSecretReference{Name: "admin-kubeconfig"},
+						},
+					},
+				}
+			}
+			kubeconfigSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: remoteNMStateNamespace, Name: "admin-kubeconfig"},
+				Data:       map[string][]byte{"kubeconfig": []byte("guest-kubeconfig")},
+			}
+			reconcileRemote = func(key types.NamespacedName) (ctrl.Result, error) {
+				return reconciler.ReconcileRemote(context.Background(), ctrl.Request{NamespacedName: key})
+			}
+		)
+		BeforeEach(func() {
+			// The fake server-side apply needs the remote client set up like the local one
+			remoteClient = setupFakeClient(newNMState())
+			reconciler.newRemoteClient = func(kubeconfig []byte, _ *runtime.Scheme) (client.Client, error) {
+				Expect(string(kubeconfig)).To(Equal("guest-kubeconfig"))
+				return remoteClient, nil
+			}
+		})
+		AfterEach(func() {
+			reconciler.newRemoteClient = nil
+			reconciler.remoteClients = nil
+		})
+		Context("and the kubeconfig secret exists at its namespace", func() {
+			var result ctrl.Result
+			BeforeEach(func() {
+				cl = setupFakeClient(newNMState(), remoteNMState(), kubeconfigSecret)
+				reconciler.Client = cl
+				reconciler.APIClient = cl
+				var err error
+				result, err = reconcileRemote(remoteNMStateKey)
+				Expect(err).ToNot(HaveOccurred())
+			})
+			It("should deploy the handler at the remote cluster without owner reference", func() {
+				ds := &appsv1.DaemonSet{}
+				Expect(remoteClient.Get(context.Background(), handlerKey, ds)).To(Succeed())
+				Expect(ds.OwnerReferences).To(BeEmpty())
+				Expect(cl.Get(context.Background(), handlerKey, ds)).To(MatchError(apierrors.IsNotFound, "IsNotFound"))
+			})
+			It("should keep both the NMState and the RemoteNMState", func() {
+				Expect(cl.Get(context.Background(), types.NamespacedName{Name: existingNMStateName}, &nmstatev1.NMState{})).To(Succeed())
+				Expect(cl.Get(context.Background(), remoteNMStateKey, &nmstatev1.RemoteNMState{})).To(Succeed())
+			})
+			It("should report the status and add the uninstall finalizer at the RemoteNMState", func() {
+				remote := &nmstatev1.RemoteNMState{}
+				Expect(cl.Get(context.Background(), remoteNMStateKey, remote)).To(Succeed())
+				Expect(remote.Finalizers).To(ContainElement(names.NMStateUninstallFinalizer))
+				Expect(remote.Status.Conditions.Find(shared.NmstateConditionAvailable)).ToNot(BeNil())
+				Expect(remote.Status.Components).To(ContainElement(HaveField("Workload", handlerKey.Name)))
+			})
+			It("should resync the remote cluster periodically", func() {
+				Expect(result.RequeueAfter).To(Equal(remoteClusterResync))
+			})
+		})
+		Context("and the RemoteNMState is uninstalled", func() {
+			var (
+				webhookServiceAccountKey = types.NamespacedName{Namespace: handlerNamespace, Name: handlerPrefix + "-nmstate-handler"}
+				certManagerKey           = types.NamespacedName{Namespace: handlerNamespace, Name: handlerPrefix + "-nmstate-cert-manager"}
+			)
+			BeforeEach(func() {
+				remoteClient = setupFakeClient(newNMState(),
+					&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: webhookKey.Namespace, Name: webhookKey.Name}},
+					&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: certManagerKey.Namespace, Name: certManagerKey.Name}},
+					&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: webhookKey.Namespace, Name: webhookKey.Name}},
+					&policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Namespace: webhookKey.Namespace, Name: webhookKey.Name}},
+					&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+						Namespace: webhookServiceAccountKey.Namespace,
+						Name:      webhookServiceAccountKey.Name,
+					}},
+				)
+				deleted := remoteNMState()
+				deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				deleted.Finalizers = []string{names.NMStateUninstallFinalizer}
+				cl = setupFakeClient(newNMState(), deleted, kubeconfigSecret)
+				reconciler.Client = cl
+				reconciler.APIClient = cl
+				_, err := reconcileRemote(remoteNMStateKey)
+				Expect(err).ToNot(HaveOccurred())
+			})
+			It("should remove what the handler and rbac manifests deployed at the remote cluster", func() {
+				Expect(remoteClient.Get(context.Background(), webhookKey, &appsv1.Deployment{})).
+					To(MatchError(apierrors.IsNotFound, "IsNotFound"))
+				Expect(remoteClient.Get(context.Background(), certManagerKey, &appsv1.Deployment{})).
+					To(MatchError(apierrors.IsNotFound, "IsNotFound"))
+				Expect(remoteClient.Get(context.Background(), webhookKey, &corev1.Service{})).
+					To(MatchError(apierrors.IsNotFound, "IsNotFound"))
+				Expect(remoteClient.Get(context.Background(), webhookKey, &policyv1.PodDisruptionBudget{})).
+					To(MatchError(apierrors.IsNotFound, "IsNotFound"))
+				Expect(remoteClient.Get(context.Background(), webhookServiceAccountKey, &corev1.ServiceAccount{})).
+					To(MatchError(apierrors.IsNotFound, "IsNotFound"))
+			})
+			It("should release the RemoteNMState", func() {
+				Expect(cl.Get(context.Background(), remoteNMStateKey, &nmstatev1.RemoteNMState{})).
+					To(MatchError(apierrors.IsNotFound, "IsNotFound"))
+			})
+		})
+		Context("and the uninstalled RemoteNMState kubeconfig secret is gone", func() {
+			BeforeEach(func() {
+				deleted := remoteNMState()
+				deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				deleted.Finalizers = []string{names.NMStateUninstallFinalizer}
+				cl = setupFakeClient(newNMState(), deleted)
+				reconciler.Client = cl
+				reconciler.APIClient = cl
+				_, err := reconcileRemote(remoteNMStateKey)
+				Expect(err).ToNot(HaveOccurred())
+			})
+			It("should release the RemoteNMState without uninstalling", func() {
+				Expect(cl.Get(context.Background(), remoteNMStateKey, &nmstatev1.RemoteNMState{})).
+					To(MatchError(apierrors.IsNotFound, "IsNotFound"))
+			})
+		})
+		Context("and another RemoteNMState references the same kubeconfig secret", func() {
+			const duplicatedNMStateName = "guest-cluster-duplicated"
+			duplicatedKey := types.NamespacedName{Namespace: remoteNMStateNamespace, Name: duplicatedNMStateName}
+			BeforeEach(func() {
+				duplicated := remoteNMState()
+				duplicated.Name = duplicatedNMStateName
+				duplicated.CreationTimestamp = metav1.NewTime(time.Now().Add(2 * time.Hour))
+				cl = setupFakeClient(newNMState(), remoteNMState(), duplicated, kubeconfigSecret)
+				reconciler.Client = cl
+				reconciler.APIClient = cl
+				_, err := reconcileRemote(duplicatedKey)
+				Expect(err).ToNot(HaveOccurred())
+			})
+			It("should remove the newer one", func() {
+				Expect(cl.Get(context.Background(), duplicatedKey, &nmstatev1.RemoteNMState{})).
+					To(MatchError(apierrors.IsNotFound, "IsNotFound"))
+				Expect(cl.Get(context.Background(), remoteNMStateKey, &nmstatev1.RemoteNMState{})).To(Succeed())
+			})
+		})
+		Context("and a RemoteNMState at another namespace has a kubeconfig secret with the same name", func() {
+			otherKey := types.NamespacedName{Namespace: "clusters-other", Name: remoteNMStateName}
+			BeforeEach(func() {
+				other := remoteNMState()
+				other.Namespace = otherKey.Namespace
+				other.CreationTimestamp = metav1.NewTime(time.Now().Add(2 * time.Hour))
+				otherSecret := kubeconfigSecret.DeepCopy()
+				otherSecret.Namespace = otherKey.Namespace
+				cl = setupFakeClient(newNMState(), remoteNMState(), other, kubeconfigSecret, otherSecret)
+				reconciler.Client = cl
+				reconciler.APIClient = cl
+				_, err := reconcileRemote(otherKey)
+				Expect(err).ToNot(HaveOccurred())
+			})
+			It("should deploy both remote clusters", func() {
+				Expect(cl.Get(context.Background(), otherKey, &nmstatev1.RemoteNMState{})).To(Succeed())
+				Expect(cl.Get(context.Background(), remoteNMStateKey, &nmstatev1.RemoteNMState{})).To(Succeed())
+			})
+		})
+		Context("and the remote client is already built", func() {
+			var (
+				remoteClientsBuilt int
+				connect            = func() (*NMStateReconciler, error) {
+					target := reconciler.forRemoteInstance(remoteNMState(), reconciler.Log)
+					return target, target.connect(context.Background(), remoteNMState())
+				}
+			)
+			BeforeEach(func() {
+				remoteClientsBuilt = 0
+				reconciler.newRemoteClient = func(_ []byte, _ *runtime.Scheme) (client.Client, error) {
+					remoteClientsBuilt++
+					return remoteClient, nil
+				}
+				reconciler.remoteClients = &remoteClients{}
+				cl = setupFakeClient(newNMState(), remoteNMState(), kubeconfigSecret.DeepCopy())
+				reconciler.Client = cl
+				reconciler.APIClient = cl
+				_, err := connect()
+				Expect(err).ToNot(HaveOccurred())
+			})
+			It("should reuse it while the kubeconfig secret does not change", func() {
+				target, err := connect()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(target.Client).To(BeIdenticalTo(remoteClient))
+				Expect(remoteClientsBuilt).To(Equal(1))
+			})
+			It("should build it again once the kubeconfig secret changes", func() {
+				secret := &corev1.Secret{}
+				Expect(cl.Get(context.Background(), client.ObjectKeyFromObject(kubeconfigSecret), secret)).To(Succeed())
+				secret.Data["kubeconfig"] = []byte("rotated-kubeconfig")
+				Expect(cl.Update(context.Background(), secret)).To(Succeed())
+				_, err := connect()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(remoteClientsBuilt).To(Equal(2))
+			})
+		})
+		Context("and the kubeconfig secret does not exist", func() {
+			BeforeEach(func() {
+				cl = setupFakeClient(newNMState(), remoteNMState())
+				reconciler.Client = cl
+				reconciler.APIClient = cl
+			})
+			It("should report it at the RemoteNMState degraded condition", func() {
+				_, err := reconcileRemote(remoteNMStateKey)
+				Expect(err).To(HaveOccurred())
+				remote := &nmstatev1.RemoteNMState{}
+				Expect(cl.Get(context.Background(), remoteNMStateKey, remote)).To(Succeed())
+				degraded := remote.Status.Conditions.Find(shared.NmstateConditionDegraded)
+				Expect(degraded).ToNot(BeNil())
+				Expect(degraded.Message).To(ContainSubstring("clusters-guest/admin-kubeconfig"))
+			})
+		})
+	})
+
+	Context("when reporting the components status", func() {
+		It("should report the deployments and daemonsets with their image", func() {
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			nmstate := &nmstatev1.NMState{}
+			Expect(cl.Get(context.Background(), types.NamespacedName{Name: existingNMStateName}, nmstate)).To(Succeed())
+			Expect(nmstate.Status.Components).To(ContainElements(
+				shared.ComponentStatus{Name: "handler", Kind: "DaemonSet", Workload: handlerKey.Name, Image: handlerImage},
+				shared.ComponentStatus{Name: "webhook", Kind: "Deployment", Workload: webhookKey.Name, Desired: 1, Image: handlerImage},
+			))
+		})
+		It("should report the nmstate and NetworkManager versions spread across the nodes", func() {
+			nodeNetworkState := func(name, nmstateVersion, networkManagerVersion string) *nmstatev1beta1.NodeNetworkState {
+				return &nmstatev1beta1.NodeNetworkState{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+					Status: shared.NodeNetworkStateStatus{
+						HandlerNmstateVersion:     nmstateVersion,
+						HostNetworkManagerVersion: networkManagerVersion,
+					},
+				}
+			}
+			cl = setupFakeClient(newNMState(),
+				nodeNetworkState("node01", "2.2.40", "1.50.0"),
+				nodeNetworkState("node02", "2.2.40", "1.48.0"),
+				nodeNetworkState("node03", "2.2.41", "1.50.0"),
+				nodeNetworkState("node04", "", ""),
+			)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			nmstate := &nmstatev1.NMState{}
+			Expect(cl.Get(context.Background(), types.NamespacedName{Name: existingNMStateName}, nmstate)).To(Succeed())
+			Expect(nmstate.Status.NmstateVersions).To(Equal([]shared.VersionCount{
+				{Version: "2.2.40", Nodes: 2},
+				{Version: "2.2.41", Nodes: 1},
+			}))
+			Expect(nmstate.Status.NetworkManagerVersions).To(Equal([]shared.VersionCount{
+				{Version: "1.48.0", Nodes: 1},
+				{Version: "1.50.0", Nodes: 2},
+			}))
+		})
+		It("should take the version from the image tag", func() {
+			Expect(imageTag("quay.io/nmstate/kubernetes-nmstate-handler:v0.85.0")).To(Equal("v0.85.0"))
+			Expect(imageTag("registry:5000/nmstate/handler")).To(BeEmpty())
+			Expect(imageTag("quay.io/nmstate/handler@sha256:abc")).To(Equal("sha256:abc"))
+		})
+	})
+
+	Context("when operator spec has per interface metrics", func() {
+		It("should add them to metrics deployment", func() {
+			nmstate := newNMState()
+			nmstate.Spec.InterfaceMetrics = &shared.InterfaceMetrics{
+				IncludeNames:         []string{"eth*", "bond*"},
+				MaxInterfacesPerNode: 10,
+			}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			deployment := &appsv1.Deployment{}
+			metricsKey := types.NamespacedName{Namespace: handlerNamespace, Name: handlerPrefix + "-nmstate-metrics"}
+			Expect(cl.Get(context.Background(), metricsKey, deployment)).To(Succeed())
+			Expect(envVariableStringPresent(
+				"INTERFACE_METRICS", `{"includeNames":["eth*","bond*"],"maxInterfacesPerNode":10}`, deployment.Spec.Template.Spec.Containers[0].Env,
+			)).To(BeTrue())
+		})
+		It("should fail reconcile with an invalid allowlist", func() {
+			nmstate := newNMState()
+			nmstate.Spec.InterfaceMetrics = &shared.InterfaceMetrics{
+				IncludeNames: []string{"eth["},
+			}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).To(MatchError(ContainSubstring("invalid interface metrics")))
+		})
+	})
+
+	Context("when operator spec has an invalid interface filter", func() {
+		It("should fail reconcile", func() {
+			nmstate := newNMState()
+			nmstate.Spec.InterfaceFilter = &shared.InterfaceFilter{
+				ExcludeNames: []string{"veth["},
+			}
+			cl = setupFakeClient(nmstate)
+			reconciler.Client = cl
+			reconciler.APIClient = cl
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: existingNMStateName},
+			})
+			Expect(err).To(MatchError(ContainSubstring("invalid interface filter")))
+		})
+	})
+
 	Context("when network policies need to be deployed", func() {
 		var (
 			request ctrl.Request
@@ -787,12 +2133,15 @@ func copyManifests(manifestsDir string) error {
 		"../../deploy/crds/nmstate.io_nodenetworkconfigurationenactments.yaml": "kubernetes-nmstate/crds/",
 		"../../deploy/crds/nmstate.io_nodenetworkconfigurationpolicies.yaml":   "kubernetes-nmstate/crds/",
 		"../../deploy/crds/nmstate.io_nodenetworkstates.yaml":                  "kubernetes-nmstate/crds/",
+		"../../deploy/crds/nmstate.io_nodenetworkinterfaces.yaml":              "kubernetes-nmstate/crds/",
 		"../../deploy/handler/namespace.yaml":                                  "kubernetes-nmstate/namespace/",
 		"../../deploy/handler/network_policy.yaml":                             "kubernetes-nmstate/netpol/handler.yaml",
 		"../../deploy/handler/operator.yaml":                                   "kubernetes-nmstate/handler/handler.yaml",
 		"../../deploy/handler/service_account.yaml":                            "kubernetes-nmstate/rbac/",
 		"../../deploy/handler/role.yaml":                                       "kubernetes-nmstate/rbac/",
 		"../../deploy/handler/role_binding.yaml":                               "kubernetes-nmstate/rbac/",
+		"../../deploy/openshift/ui-plugin/deployment.yaml":                     "kubernetes-nmstate/openshift/ui-plugin/",
+		"../../deploy/openshift/ui-plugin/pod_disruption_budget.yaml":          "kubernetes-nmstate/openshift/ui-plugin/",
 	}
 
 	for src, dest := range srcToDest {
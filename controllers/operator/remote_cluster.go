@@ -0,0 +1,309 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/nmstate/kubernetes-nmstate/api/names"
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstatev1 "github.com/nmstate/kubernetes-nmstate/api/v1"
+	"github.com/nmstate/kubernetes-nmstate/pkg/cluster"
+)
+
+const (
+	// remoteClusterResync is how often a remote cluster is reconciled, the
+	// operator does not watch the objects it deploys there
+	remoteClusterResync = time.Minute
+	// defaultKubeconfigKey is the Secret data key of the remote kubeconfig
+	defaultKubeconfigKey = "kubeconfig"
+)
+
+// newRemoteClient returns a client for the cluster of a kubeconfig
+func newRemoteClient(kubeconfig []byte, scheme *runtime.Scheme) (client.Client, error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing kubeconfig: %w", err)
+	}
+	return client.New(config, client.Options{Scheme: scheme})
+}
+
+// remoteClient is a remote cluster client built from the kubeconfig Secret at
+// resourceVersion.
+type remoteClient struct {
+	resourceVersion string
+	key             string
+	client          client.Client
+	isOpenShift     bool
+}
+
+// remoteClients keeps the remote cluster clients by kubeconfig Secret so
+// they are not built again at every reconcile.
+type remoteClients struct {
+	mutex   sync.Mutex
+	clients map[types.NamespacedName]remoteClient
+}
+
+func (c *remoteClients) get(secret *corev1.Secret, key string) (remoteClient, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	cached, ok := c.clients[client.ObjectKeyFromObject(secret)]
+	if !ok || cached.resourceVersion != secret.ResourceVersion || cached.key != key {
+		return remoteClient{}, false
+	}
+	return cached, true
+}
+
+func (c *remoteClients) set(secret *corev1.Secret, cached remoteClient) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.clients == nil {
+		c.clients = map[types.NamespacedName]remoteClient{}
+	}
+	c.clients[client.ObjectKeyFromObject(secret)] = cached
+}
+
+func (c *remoteClients) delete(secret types.NamespacedName) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.clients, secret)
+}
+
+// kubeconfigSecret returns the key of the RemoteNMState kubeconfig Secret,
+// it is at the RemoteNMState namespace.
+func kubeconfigSecret(remoteInstance *nmstatev1.RemoteNMState) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: remoteInstance.Namespace,
+		Name:      remoteInstance.Spec.RemoteCluster.KubeconfigSecret.Name,
+	}
+}
+
+// kubeconfigKey returns the data key of the RemoteNMState kubeconfig
+func kubeconfigKey(remoteInstance *nmstatev1.RemoteNMState) string {
+	if key := remoteInstance.Spec.RemoteCluster.KubeconfigSecret.Key; key != "" {
+		return key
+	}
+	return defaultKubeconfigKey
+}
+
+// remoteNMStateView returns the NMState the RemoteNMState deploys, the
+// manifests are rendered from it as for the local one.
+func remoteNMStateView(remoteInstance *nmstatev1.RemoteNMState) *nmstatev1.NMState {
+	return &nmstatev1.NMState{
+		ObjectMeta: *remoteInstance.ObjectMeta.DeepCopy(),
+		Spec:       *remoteInstance.Spec.NMStateSpec.DeepCopy(),
+		Status:     *remoteInstance.Status.DeepCopy(),
+	}
+}
+
+// +kubebuilder:rbac:groups=nmstate.io,resources=remotenmstates,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=nmstate.io,resources=remotenmstates/finalizers,verbs=update
+// +kubebuilder:rbac:groups=nmstate.io,resources=remotenmstates/status,verbs=get;update;patch
+
+// ReconcileRemote deploys kubernetes-nmstate into the cluster of the
+// RemoteNMState kubeconfig Secret. The operator does not watch the objects
+// it deploys there, the remote cluster is reconciled periodically.
+func (r *NMStateReconciler) ReconcileRemote(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("remotenmstate", req.NamespacedName)
+	log.Info("Starting Reconcile")
+	remoteInstance := &nmstatev1.RemoteNMState{}
+	if err := r.Get(ctx, req.NamespacedName, remoteInstance); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if r.remoteClients == nil {
+		r.remoteClients = &remoteClients{}
+	}
+	target := r.forRemoteInstance(remoteInstance, log)
+	instance := remoteNMStateView(remoteInstance)
+
+	// We only want one RemoteNMState per kubeconfig Secret, ignore anything
+	// after that.
+	instanceList := &nmstatev1.RemoteNMStateList{}
+	if err := r.List(ctx, instanceList, client.InNamespace(req.Namespace)); err != nil {
+		err = errors.Wrap(err, "failed listing all RemoteNMState instances")
+		target.setDegradedCondition(ctx, instance, shared.NmstateInternalError, err.Error())
+		return ctrl.Result{}, err
+	}
+	clusterInstances := []nmstatev1.RemoteNMState{}
+	for i := range instanceList.Items {
+		if kubeconfigSecret(&instanceList.Items[i]) == kubeconfigSecret(remoteInstance) &&
+			kubeconfigKey(&instanceList.Items[i]) == kubeconfigKey(remoteInstance) {
+			clusterInstances = append(clusterInstances, instanceList.Items[i])
+		}
+	}
+	sort.Slice(clusterInstances, func(i, j int) bool {
+		return clusterInstances[j].CreationTimestamp.After(clusterInstances[i].CreationTimestamp.Time)
+	})
+	if len(clusterInstances) > 0 && clusterInstances[0].Name != req.Name {
+		if instance.DeletionTimestamp != nil {
+			// The ignored RemoteNMState deployed nothing, there is nothing to uninstall
+			return ctrl.Result{}, target.removeUninstallFinalizer(ctx, instance)
+		}
+		log.Info("Ignoring RemoteNMState.nmstate.io because one already exists for its kubeconfig Secret")
+		if err := r.Delete(ctx, remoteInstance); err != nil {
+			log.Error(err, "failed to remove RemoteNMState.nmstate.io instance")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if instance.DeletionTimestamp != nil {
+		if !controllerutil.ContainsFinalizer(instance, names.NMStateUninstallFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		// A remote cluster that cannot be reached anymore is not
+		// uninstalled, it may be gone already.
+		if err := target.connect(ctx, remoteInstance); err != nil {
+			log.Error(err, "Skipping the remote cluster uninstall")
+			target.recordEvent(instance, corev1.EventTypeWarning, Uninstalled,
+				fmt.Sprintf("skipping the remote cluster uninstall: %v", err))
+			return ctrl.Result{}, target.removeUninstallFinalizer(ctx, instance)
+		}
+		result, err := target.finalize(ctx, instance)
+		if err == nil && !controllerutil.ContainsFinalizer(instance, names.NMStateUninstallFinalizer) {
+			r.remoteClients.delete(kubeconfigSecret(remoteInstance))
+		}
+		return result, err
+	}
+	if err := target.addUninstallFinalizer(ctx, instance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	overlays, err := r.manifestOverlays(ctx, instance)
+	if err != nil {
+		target.setManifestOverlaysCondition(instance, err)
+		target.setDegradedCondition(ctx, instance, shared.NmstateInvalidManifestOverlay, err.Error())
+		return ctrl.Result{}, err
+	}
+	if err := target.connect(ctx, remoteInstance); err != nil {
+		target.setDegradedCondition(ctx, instance, shared.NmstateInternalError, err.Error())
+		return ctrl.Result{}, err
+	}
+	target.overlays = overlays
+	result, err := target.reconcileInstance(ctx, instance)
+	if err != nil {
+		return result, err
+	}
+	if result.RequeueAfter == 0 || result.RequeueAfter > remoteClusterResync {
+		result.RequeueAfter = remoteClusterResync
+	}
+	return result, nil
+}
+
+// forRemoteInstance returns the reconciler that deploys the RemoteNMState,
+// its status, finalizer and events are written to the RemoteNMState at the
+// operator cluster. The objects at the remote cluster have no owner
+// reference, the RemoteNMState is not there.
+func (r *NMStateReconciler) forRemoteInstance(remoteInstance *nmstatev1.RemoteNMState, log logr.Logger) *NMStateReconciler {
+	remote := *r
+	remote.remoteInstance = remoteInstance
+	remote.instanceClient = r.nmstateClient()
+	remote.remote = true
+	remote.Log = log
+	return &remote
+}
+
+// connect points the reconciler clients to the remote cluster with a client
+// built from the kubeconfig Secret, the client is kept until the Secret
+// changes.
+func (r *NMStateReconciler) connect(ctx context.Context, remoteInstance *nmstatev1.RemoteNMState) error {
+	secretKey := kubeconfigSecret(remoteInstance)
+	secret := &corev1.Secret{}
+	if err := r.APIClient.Get(ctx, secretKey, secret); err != nil {
+		return fmt.Errorf("failed getting remote cluster kubeconfig secret %s: %w", secretKey, err)
+	}
+	key := kubeconfigKey(remoteInstance)
+	kubeconfig, ok := secret.Data[key]
+	if !ok {
+		return fmt.Errorf("remote cluster kubeconfig secret %s has no %q key", secretKey, key)
+	}
+	cached, ok := r.remoteClients.get(secret, key)
+	if !ok {
+		newClient := r.newRemoteClient
+		if newClient == nil {
+			newClient = newRemoteClient
+		}
+		cl, err := newClient(kubeconfig, r.Scheme)
+		if err != nil {
+			return fmt.Errorf("failed creating remote cluster client: %w", err)
+		}
+		cached = remoteClient{resourceVersion: secret.ResourceVersion, key: key, client: cl}
+		cached.isOpenShift, err = cluster.IsOpenShift(cl)
+		if err != nil {
+			r.Log.Info("Warning: could not determine if the remote cluster is OpenShift, assuming not", "error", err.Error())
+		} else {
+			r.remoteClients.set(secret, cached)
+		}
+	}
+
+	r.Client = cached.client
+	r.APIClient = cached.client
+	r.IsOpenShift = cached.isOpenShift
+	return nil
+}
+
+// nmstateClient returns the client of the cluster with the NMState
+func (r *NMStateReconciler) nmstateClient() client.Client {
+	if r.instanceClient != nil {
+		return r.instanceClient
+	}
+	return r.Client
+}
+
+// instanceObject returns the object the NMState is read from with its
+// metadata and status, the RemoteNMState for a remote cluster.
+func (r *NMStateReconciler) instanceObject(instance *nmstatev1.NMState) client.Object {
+	if r.remoteInstance == nil {
+		return instance
+	}
+	instance.ObjectMeta.DeepCopyInto(&r.remoteInstance.ObjectMeta)
+	instance.Status.DeepCopyInto(&r.remoteInstance.Status)
+	return r.remoteInstance
+}
+
+// syncInstance copies the metadata of the written RemoteNMState back to the
+// NMState.
+func (r *NMStateReconciler) syncInstance(instance *nmstatev1.NMState) {
+	if r.remoteInstance != nil {
+		r.remoteInstance.ObjectMeta.DeepCopyInto(&instance.ObjectMeta)
+	}
+}
+
+// recordEvent records an event at the object the NMState is read from
+func (r *NMStateReconciler) recordEvent(instance *nmstatev1.NMState, eventType, reason, message string) {
+	if r.Recorder != nil {
+		r.Recorder.Event(r.instanceObject(instance), eventType, reason, message)
+	}
+}
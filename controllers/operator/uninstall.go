@@ -0,0 +1,421 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/nmstate/kubernetes-nmstate/api/names"
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstatev1 "github.com/nmstate/kubernetes-nmstate/api/v1"
+	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
+	"github.com/nmstate/kubernetes-nmstate/pkg/environment"
+	"github.com/nmstate/kubernetes-nmstate/pkg/render"
+)
+
+const (
+	// Uninstalled is the reason of the event emitted with what the uninstall
+	// removed
+	Uninstalled = "Uninstalled"
+	// uninstallRequeue is how often an uninstall waiting for the progressing
+	// policies is checked
+	uninstallRequeue = 10 * time.Second
+)
+
+func (r *NMStateReconciler) addUninstallFinalizer(ctx context.Context, instance *nmstatev1.NMState) error {
+	if controllerutil.ContainsFinalizer(instance, names.NMStateUninstallFinalizer) {
+		return nil
+	}
+	if err := r.patchFinalizers(ctx, instance, controllerutil.AddFinalizer); err != nil {
+		return fmt.Errorf("failed adding uninstall finalizer: %w", err)
+	}
+	return nil
+}
+
+func (r *NMStateReconciler) removeUninstallFinalizer(ctx context.Context, instance *nmstatev1.NMState) error {
+	if !controllerutil.ContainsFinalizer(instance, names.NMStateUninstallFinalizer) {
+		return nil
+	}
+	if err := r.patchFinalizers(ctx, instance, controllerutil.RemoveFinalizer); err != nil {
+		return fmt.Errorf("failed removing uninstall finalizer: %w", err)
+	}
+	return nil
+}
+
+// patchFinalizers patches the uninstall finalizer of the object the NMState
+// is read from.
+func (r *NMStateReconciler) patchFinalizers(
+	ctx context.Context,
+	instance *nmstatev1.NMState,
+	update func(client.Object, string) bool,
+) error {
+	obj := r.instanceObject(instance)
+	patch := client.MergeFromWithOptions(obj.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+	update(obj, names.NMStateUninstallFinalizer)
+	if err := r.nmstateClient().Patch(ctx, obj, patch); err != nil {
+		return err
+	}
+	r.syncInstance(instance)
+	return nil
+}
+
+// finalize uninstalls what the deleted NMState deployed and then releases it.
+func (r *NMStateReconciler) finalize(ctx context.Context, instance *nmstatev1.NMState) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(instance, names.NMStateUninstallFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	uninstall, done, err := r.uninstall(ctx, instance)
+	instance.Status.Uninstall = &uninstall
+	if statusErr := r.applyStatus(ctx, instance); statusErr != nil {
+		r.Log.Error(statusErr, "failed reporting the uninstall at NMState status")
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed uninstalling: %w", err)
+	}
+	if !done {
+		return ctrl.Result{RequeueAfter: uninstallRequeue}, nil
+	}
+
+	message := "nothing to remove"
+	if len(uninstall.Removed) > 0 {
+		message = "removed " + strings.Join(uninstall.Removed, ", ")
+	}
+	r.Log.Info("Uninstall complete", "removed", uninstall.Removed)
+	r.recordEvent(instance, corev1.EventTypeNormal, Uninstalled, message)
+	return ctrl.Result{}, r.removeUninstallFinalizer(ctx, instance)
+}
+
+// uninstall removes what the operator deployed and is not garbage collected
+// with the NMState, it is done when nothing is left to wait for. The handlers
+// are stopped first, so they do not report the node network states again.
+func (r *NMStateReconciler) uninstall(ctx context.Context, instance *nmstatev1.NMState) (shared.UninstallStatus, bool, error) {
+	status := shared.UninstallStatus{}
+	config := shared.UninstallConfiguration{}
+	if instance.Spec.Uninstall != nil {
+		config = *instance.Spec.Uninstall
+	}
+
+	if config.WaitForProgressingPolicies {
+		progressing, err := r.progressingPolicies(ctx)
+		if err != nil {
+			return status, false, err
+		}
+		if len(progressing) > 0 {
+			status.Message = fmt.Sprintf("waiting for the progressing policies: %s", strings.Join(progressing, ", "))
+			return status, false, nil
+		}
+	}
+
+	steps := []func(context.Context, *nmstatev1.NMState) ([]string, error){
+		r.removeWebhookConfigurations,
+		r.removeHandlers,
+		r.removeHandlerPoolLabels,
+		r.removeNetworkPolicies,
+		r.removeConsolePlugin,
+	}
+	// The owned objects are garbage collected with the NMState, it is not at
+	// the remote clusters
+	if r.remote {
+		steps = append(steps, r.removeOwnedManifests)
+	}
+	// The enactments and node network states go with their CRDs
+	if config.RemoveCRDs {
+		steps = append(steps, r.removeCRDs)
+	} else {
+		steps = append(steps, r.removeEnactmentsAndNodeNetworkStates)
+	}
+	// The histories are owned by the nodes, they are not garbage collected
+	steps = append(steps, r.removeNodeNetworkStateHistories)
+	for _, step := range steps {
+		removed, err := step(ctx, instance)
+		status.Removed = append(status.Removed, removed...)
+		if err != nil {
+			return status, false, err
+		}
+	}
+	return status, true, nil
+}
+
+func (r *NMStateReconciler) progressingPolicies(ctx context.Context) ([]string, error) {
+	policies := nmstatev1.NodeNetworkConfigurationPolicyList{}
+	if err := r.APIClient.List(ctx, &policies); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed listing policies: %w", err)
+	}
+	progressing := []string{}
+	for i := range policies.Items {
+		condition := policies.Items[i].Status.Conditions.Find(shared.NodeNetworkConfigurationPolicyConditionProgressing)
+		if condition != nil && condition.Status == corev1.ConditionTrue {
+			progressing = append(progressing, policies.Items[i].Name)
+		}
+	}
+	return progressing, nil
+}
+
+// deleteObjects deletes the objects and returns the kind and name of the
+// ones that existed.
+func (r *NMStateReconciler) deleteObjects(ctx context.Context, objs ...client.Object) ([]string, error) {
+	removed := []string{}
+	for _, obj := range objs {
+		if err := r.Delete(ctx, obj); err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			return removed, fmt.Errorf("failed deleting %s %s: %w", r.kindOf(obj), client.ObjectKeyFromObject(obj), err)
+		}
+		removed = append(removed, fmt.Sprintf("%s %s", r.kindOf(obj), strings.TrimPrefix(client.ObjectKeyFromObject(obj).String(), "/")))
+	}
+	return removed, nil
+}
+
+func (r *NMStateReconciler) kindOf(obj client.Object) string {
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return fmt.Sprintf("%T", obj)
+	}
+	return gvk.Kind
+}
+
+// handlerPrefixed returns the name of a resource with the handler prefix as
+// the handlerPrefix template does
+func handlerPrefixed(name string) string {
+	if prefix := environment.GetEnvVar("HANDLER_PREFIX", ""); prefix != "" {
+		return prefix + "-" + name
+	}
+	return name
+}
+
+// removeWebhookConfigurations removes the admission webhook and policies, the
+// policies could not be deleted once the webhook is gone otherwise.
+func (r *NMStateReconciler) removeWebhookConfigurations(ctx context.Context, _ *nmstatev1.NMState) ([]string, error) {
+	return r.deleteObjects(ctx,
+		&admissionregistrationv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: handlerPrefixed("nmstate")}},
+		&admissionregistrationv1.ValidatingAdmissionPolicyBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: handlerPrefixed("nncp-validation-policy-binding")},
+		},
+		&admissionregistrationv1.ValidatingAdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: handlerPrefixed("nncp-validation-policy")}},
+	)
+}
+
+func (r *NMStateReconciler) removeHandlers(ctx context.Context, _ *nmstatev1.NMState) ([]string, error) {
+	daemonSets := appsv1.DaemonSetList{}
+	if err := r.APIClient.List(ctx, &daemonSets,
+		client.InNamespace(environment.GetEnvVar("HANDLER_NAMESPACE", "")),
+		client.MatchingLabels{componentLabelKey: componentLabelPrefix + "handler"},
+	); err != nil {
+		return nil, fmt.Errorf("failed listing handler daemonsets: %w", err)
+	}
+	objs := []client.Object{}
+	for i := range daemonSets.Items {
+		objs = append(objs, &daemonSets.Items[i])
+	}
+	return r.deleteObjects(ctx, objs...)
+}
+
+// removeHandlerPoolLabels reports the number of nodes unlabeled, the label
+// tells the handler pool of the node.
+func (r *NMStateReconciler) removeHandlerPoolLabels(ctx context.Context, _ *nmstatev1.NMState) ([]string, error) {
+	nodes := corev1.NodeList{}
+	if err := r.APIClient.List(ctx, &nodes, client.HasLabels{names.HandlerPoolLabelKey}); err != nil {
+		return nil, fmt.Errorf("failed listing handler pool nodes: %w", err)
+	}
+	unlabeled := 0
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		patch := client.MergeFrom(node.DeepCopy())
+		delete(node.Labels, names.HandlerPoolLabelKey)
+		if err := r.APIClient.Patch(ctx, node, patch); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed removing handler pool label from node %s: %w", node.Name, err)
+		}
+		unlabeled++
+	}
+	if unlabeled == 0 {
+		return nil, nil
+	}
+	return []string{fmt.Sprintf("%d node handler pool labels", unlabeled)}, nil
+}
+
+// removeEnactmentsAndNodeNetworkStates reports the number of removed objects,
+// there is one per node or per node and policy.
+func (r *NMStateReconciler) removeEnactmentsAndNodeNetworkStates(ctx context.Context, _ *nmstatev1.NMState) ([]string, error) {
+	removed := []string{}
+	lists := map[string]client.ObjectList{
+		"NodeNetworkConfigurationEnactments": &nmstatev1beta1.NodeNetworkConfigurationEnactmentList{},
+		"NodeNetworkStates":                  &nmstatev1beta1.NodeNetworkStateList{},
+	}
+	for _, kind := range []string{"NodeNetworkConfigurationEnactments", "NodeNetworkStates"} {
+		list := lists[kind]
+		if err := r.APIClient.List(ctx, list); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return removed, fmt.Errorf("failed listing %s: %w", kind, err)
+		}
+		objs := []client.Object{}
+		if err := meta.EachListItem(list, func(obj runtime.Object) error {
+			objs = append(objs, obj.(client.Object))
+			return nil
+		}); err != nil {
+			return removed, err
+		}
+		deleted, err := r.deleteObjects(ctx, objs...)
+		if len(deleted) > 0 {
+			removed = append(removed, fmt.Sprintf("%d %s", len(deleted), kind))
+		}
+		if err != nil {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+// removeNodeNetworkStateHistories reports the number of removed histories,
+// there is one per node.
+func (r *NMStateReconciler) removeNodeNetworkStateHistories(ctx context.Context, _ *nmstatev1.NMState) ([]string, error) {
+	histories := corev1.ConfigMapList{}
+	if err := r.APIClient.List(ctx, &histories,
+		client.InNamespace(environment.GetEnvVar("HANDLER_NAMESPACE", "")),
+		client.HasLabels{shared.NodeNetworkStateHistoryLabel},
+	); err != nil {
+		return nil, fmt.Errorf("failed listing NodeNetworkState histories: %w", err)
+	}
+	objs := []client.Object{}
+	for i := range histories.Items {
+		objs = append(objs, &histories.Items[i])
+	}
+	deleted, err := r.deleteObjects(ctx, objs...)
+	if len(deleted) == 0 {
+		return nil, err
+	}
+	return []string{fmt.Sprintf("%d NodeNetworkState histories", len(deleted))}, err
+}
+
+func (r *NMStateReconciler) removeNetworkPolicies(ctx context.Context, instance *nmstatev1.NMState) ([]string, error) {
+	objs, err := renderManifests(r.networkPoliciesRenderData(), "netpol")
+	if err != nil {
+		return nil, err
+	}
+	if r.IsOpenShift {
+		uiPluginObjs, err := renderManifests(uiPluginRenderData(instance), uiPluginDirectory)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, uiPluginObjs...)
+	}
+	return r.deleteObjects(ctx, renderedOfKind(objs, "NetworkPolicy")...)
+}
+
+// removeConsolePlugin disables the console plugin at the Console and removes
+// its registration
+func (r *NMStateReconciler) removeConsolePlugin(ctx context.Context, instance *nmstatev1.NMState) ([]string, error) {
+	if !r.IsOpenShift {
+		return nil, nil
+	}
+	pluginName := environment.GetEnvVar("PLUGIN_NAME", "nmstate-console-plugin")
+	consoleObj := &unstructured.Unstructured{}
+	consoleObj.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "operator.openshift.io",
+		Version: "v1",
+		Kind:    "Console",
+	})
+	if err := r.Get(ctx, client.ObjectKey{Name: "cluster"}, consoleObj); err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed getting consoles.operator.openshift.io resource: %w", err)
+	} else if err == nil {
+		plugins, _, _ := unstructured.NestedStringSlice(consoleObj.Object, "spec", "plugins")
+		if stringInSlice(pluginName, plugins) {
+			plugins = slices.DeleteFunc(plugins, func(plugin string) bool { return plugin == pluginName })
+			if err := unstructured.SetNestedStringSlice(consoleObj.Object, plugins, "spec", "plugins"); err != nil {
+				return nil, fmt.Errorf("could not set spec.plugins: %w", err)
+			}
+			if err := r.Update(ctx, consoleObj); err != nil {
+				return nil, fmt.Errorf("failed disabling the console plugin: %w", err)
+			}
+		}
+	}
+
+	objs, err := renderManifests(uiPluginRenderData(instance), uiPluginDirectory)
+	if err != nil {
+		return nil, err
+	}
+	return r.deleteObjects(ctx, renderedOfKind(objs, "ConsolePlugin")...)
+}
+
+func (r *NMStateReconciler) removeCRDs(ctx context.Context, _ *nmstatev1.NMState) ([]string, error) {
+	objs, err := renderManifests(render.MakeRenderData(), "crds")
+	if err != nil {
+		return nil, err
+	}
+	return r.deleteObjects(ctx, renderedOfKind(objs, "CustomResourceDefinition")...)
+}
+
+// removeOwnedManifests removes the objects rendered from the manifests
+// directories the NMState owns, the last applied ones first.
+func (r *NMStateReconciler) removeOwnedManifests(ctx context.Context, instance *nmstatev1.NMState) ([]string, error) {
+	directories, err := r.manifestsDirectories(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	objs := []client.Object{}
+	for _, directory := range slices.Backward(directories) {
+		if !directory.owned {
+			continue
+		}
+		rendered, err := renderManifests(directory.data, directory.directory)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range rendered {
+			objs = append(objs, obj)
+		}
+	}
+	return r.deleteObjects(ctx, objs...)
+}
+
+func renderedOfKind(objs []*unstructured.Unstructured, kind string) []client.Object {
+	ofKind := []client.Object{}
+	for _, obj := range objs {
+		if obj.GetKind() == kind {
+			ofKind = append(ofKind, obj)
+		}
+	}
+	return ofKind
+}
@@ -28,14 +28,20 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	yaml "sigs.k8s.io/yaml"
 
 	"github.com/nmstate/kubernetes-nmstate/api/names"
 	"github.com/nmstate/kubernetes-nmstate/api/shared"
 	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
+	"github.com/nmstate/kubernetes-nmstate/pkg/environment"
+	"github.com/nmstate/kubernetes-nmstate/pkg/monitoring"
 	"github.com/nmstate/kubernetes-nmstate/pkg/nmstatectl"
 	"github.com/nmstate/kubernetes-nmstate/pkg/probe"
+	"github.com/nmstate/kubernetes-nmstate/pkg/state"
+	"github.com/nmstate/kubernetes-nmstate/pkg/tracing"
 )
 
 var log = logf.Log.WithName("client")
@@ -77,6 +83,7 @@ func InitializeNodeNetworkState(ctx context.Context, cli client.Client, node *co
 func CreateOrUpdateNodeNetworkState(
 	ctx context.Context,
 	cli client.Client,
+	apiReader client.Reader,
 	node *corev1.Node,
 	observedState shared.State,
 	nns *nmstatev1beta1.NodeNetworkState,
@@ -89,28 +96,51 @@ func CreateOrUpdateNodeNetworkState(
 			return err
 		}
 	}
-	return UpdateCurrentState(ctx, cli, nns, observedState, versions)
+	return UpdateCurrentState(ctx, cli, apiReader, nns, observedState, versions)
 }
 
+// UpdateCurrentState patches only the changed NodeNetworkState status fields,
+// retrying with the latest NodeNetworkState on conflicts. The latest one is
+// read with the apiReader since the cache may not have it yet.
 func UpdateCurrentState(
 	ctx context.Context,
 	cli client.Client,
+	apiReader client.Reader,
 	nodeNetworkState *nmstatev1beta1.NodeNetworkState,
 	observedState shared.State,
 	versions *DependencyVersions,
 ) error {
-	if observedState.String() == nodeNetworkState.Status.CurrentState.String() {
-		log.Info("Skipping NodeNetworkState update, node network configuration not changed")
-		return nil
-	}
+	firstTry := true
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if !firstTry {
+			if err := apiReader.Get(ctx, client.ObjectKeyFromObject(nodeNetworkState), nodeNetworkState); err != nil {
+				return err
+			}
+		}
+		firstTry = false
+
+		if observedState.String() == nodeNetworkState.Status.CurrentState.String() {
+			log.Info("Skipping NodeNetworkState update, node network configuration not changed")
+			return nil
+		}
+
+		changedSections, err := state.ChangedSections(nodeNetworkState.Status.CurrentState, observedState)
+		if err != nil {
+			return errors.Wrap(err, "failed calculating NodeNetworkState changed sections")
+		}
+
+		original := nodeNetworkState.DeepCopy()
+		now := metav1.Time{Time: time.Now()}
 
-	nodeNetworkState.Status.HandlerNmstateVersion = versions.HandlerNmstateVersion
-	nodeNetworkState.Status.HostNetworkManagerVersion = versions.HostNetworkManagerVersion
+		nodeNetworkState.Status.HandlerNmstateVersion = versions.HandlerNmstateVersion
+		nodeNetworkState.Status.HostNetworkManagerVersion = versions.HostNetworkManagerVersion
 
-	nodeNetworkState.Status.CurrentState = observedState
-	nodeNetworkState.Status.LastSuccessfulUpdateTime = metav1.Time{Time: time.Now()}
+		nodeNetworkState.Status.CurrentState = observedState
+		nodeNetworkState.Status.LastSuccessfulUpdateTime = now
+		updateLastChangeTime(&nodeNetworkState.Status, changedSections, now)
 
-	err := cli.Status().Update(ctx, nodeNetworkState)
+		return cli.Status().Patch(ctx, nodeNetworkState, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+	})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return errors.Wrap(err, "Request object not found, could have been deleted after reconcile request")
@@ -122,6 +152,67 @@ func UpdateCurrentState(
 	return nil
 }
 
+func updateLastChangeTime(status *shared.NodeNetworkStateStatus, changedSections []string, now metav1.Time) {
+	if status.LastChangeTime == nil {
+		status.LastChangeTime = map[string]metav1.Time{}
+	}
+	currentSections := map[string]any{}
+	// The state was already parsed to calculate the changed sections
+	_ = yaml.Unmarshal(status.CurrentState.Raw, &currentSections)
+	for _, section := range changedSections {
+		if _, found := currentSections[section]; found {
+			status.LastChangeTime[section] = now
+		} else {
+			delete(status.LastChangeTime, section)
+		}
+	}
+}
+
+// SetNodeNetworkStateCondition patches the NodeNetworkState status with
+// the condition, retrying with the latest NodeNetworkState on conflicts.
+func SetNodeNetworkStateCondition(
+	ctx context.Context,
+	cli client.Client,
+	name string,
+	conditionType shared.ConditionType,
+	status corev1.ConditionStatus,
+	reason shared.ConditionReason,
+	message string,
+) error {
+	return SetNodeNetworkStateConditions(ctx, cli, name, shared.ConditionList{
+		shared.NewCondition(conditionType, status, reason, message),
+	})
+}
+
+// SetNodeNetworkStateConditions patches the NodeNetworkState status with the
+// conditions if any of them changed, retrying with the latest NodeNetworkState
+// on conflicts.
+func SetNodeNetworkStateConditions(ctx context.Context, cli client.Client, name string, conditions shared.ConditionList) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		nodeNetworkState := &nmstatev1beta1.NodeNetworkState{}
+		if err := cli.Get(ctx, client.ObjectKey{Name: name}, nodeNetworkState); err != nil {
+			return err
+		}
+		changed := false
+		for _, condition := range conditions {
+			current := nodeNetworkState.Status.Conditions.Find(condition.Type)
+			if current == nil || current.Status != condition.Status ||
+				current.Reason != condition.Reason || current.Message != condition.Message {
+				changed = true
+				break
+			}
+		}
+		if !changed {
+			return nil
+		}
+		original := nodeNetworkState.DeepCopy()
+		for _, condition := range conditions {
+			nodeNetworkState.Status.Conditions.Set(condition.Type, condition.Status, condition.Reason, condition.Message)
+		}
+		return cli.Status().Patch(ctx, nodeNetworkState, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+	})
+}
+
 func ExecuteCommand(command string, arguments ...string) (string, error) {
 	cmd := exec.CommandContext(context.TODO(), command, arguments...)
 	var stdout, stderr bytes.Buffer
@@ -134,49 +225,121 @@ func ExecuteCommand(command string, arguments ...string) (string, error) {
 	return string(bytes.Trim(stdout.Bytes(), "\n")), nil
 }
 
-func rollback(ctx context.Context, cli client.Client, probes []probe.Probe, cause error) error {
+// RollbackError is returned when the desired state was rolled back after
+// failing the probes
+type RollbackError struct {
+	error
+}
+
+func (e RollbackError) Unwrap() error {
+	return e.error
+}
+
+// IsRollback returns true if the desired state apply was rolled back
+func IsRollback(err error) bool {
+	return errors.As(err, &RollbackError{})
+}
+
+func rollback(ctx context.Context, cli client.Client, probes []probe.Probe, cause error, transcript *Transcript) error {
 	message := fmt.Sprintf("rolling back desired state configuration: %s", cause)
+	transcript.Logf("%s", message)
+	_, span := tracing.Start(ctx, "nmstatectl.Rollback")
+	start := time.Now()
 	err := nmstatectl.Rollback()
+	observeApplyDuration("rollback", start, err)
+	tracing.End(span, err)
 	if err != nil {
+		transcript.Logf("nmstatectl rollback failed: %v", err)
 		return errors.Wrap(err, message)
 	}
 
 	// wait for system to settle after rollback
+	transcript.Logf("running probes after rollback")
 	probesErr := probe.Run(ctx, cli, probes)
 	if probesErr != nil {
-		return errors.Wrap(errors.Wrap(probesErr, "failed running probes after rollback"), message)
+		transcript.Logf("probes failed after rollback: %v", probesErr)
+		return RollbackError{errors.Wrap(errors.Wrap(probesErr, "failed running probes after rollback"), message)}
 	}
-	return errors.New(message)
+	transcript.Logf("probes succeeded after rollback")
+	return RollbackError{errors.New(message)}
+}
+
+func observeApplyDuration(operation string, start time.Time, err error) {
+	monitoring.ApplyDuration.WithLabelValues(environment.NodeName(), operation, monitoring.Result(err)).
+		Observe(time.Since(start).Seconds())
+}
+
+// ApplyOptions tune a single desired state apply
+type ApplyOptions struct {
+	// Verbose runs nmstatectl with verbose logging
+	Verbose bool
+	// Transcript collects the apply, probes and rollback steps if set
+	Transcript *Transcript
 }
 
-func ApplyDesiredState(ctx context.Context, cli client.Client, desiredState shared.State) (string, error) {
+func ApplyDesiredState(ctx context.Context, cli client.Client, desiredState shared.State, options ApplyOptions) (string, error) {
 	if string(desiredState.Raw) == "" {
+		options.Transcript.Logf("ignoring empty desired state")
 		return "Ignoring empty desired state", nil
 	}
 
+	ctx, span := tracing.Start(ctx, "ApplyDesiredState")
+	output, err := applyDesiredState(ctx, cli, desiredState, options)
+	tracing.End(span, err)
+	return output, err
+}
+
+func applyDesiredState(ctx context.Context, cli client.Client, desiredState shared.State, options ApplyOptions) (string, error) {
+	transcript := options.Transcript
+
 	// Before apply we get the probes that are working fine, they should be
 	// working fine after apply
 	probes := probe.Select(ctx, cli)
+	transcript.Logf("selected %d probes", len(probes))
 
 	// Rollback before Apply to remove pending checkpoints (for example handler pod restarted
 	// before Commit)
 	nmstatectl.Rollback()
 
-	setOutput, err := nmstatectl.Set(desiredState, DesiredStateConfigurationTimeout)
+	// nmstatectl prints the applied state, it ends up at logs and
+	// enactment conditions so it has to be redacted
+	setOptions := nmstatectl.SetOptions{Verbose: options.Verbose}
+	if transcript != nil {
+		transcript.Logf("applying desired state:\n%s", state.RedactString(desiredState.String()))
+		setOptions.Logs = transcript
+	}
+	_, setSpan := tracing.Start(ctx, "nmstatectl.Set")
+	start := time.Now()
+	setOutput, err := nmstatectl.SetWithOptions(desiredState, DesiredStateConfigurationTimeout, setOptions)
+	err = state.RedactError(err)
+	observeApplyDuration("apply", start, err)
+	tracing.End(setSpan, err)
+	setOutput = state.RedactString(setOutput)
 	if err != nil {
+		transcript.Logf("nmstatectl apply failed: %v", err)
 		return setOutput, err
 	}
+	transcript.Logf("nmstatectl apply succeeded")
 
+	transcript.Logf("running probes")
 	err = probe.Run(ctx, cli, probes)
 	if err != nil {
-		return "", rollback(ctx, cli, probes, errors.Wrap(err, "failed runnig probes after network changes"))
+		transcript.Logf("probes failed: %v", err)
+		return "", rollback(ctx, cli, probes, errors.Wrap(err, "failed runnig probes after network changes"), transcript)
 	}
+	transcript.Logf("probes succeeded")
 
+	_, commitSpan := tracing.Start(ctx, "nmstatectl.Commit")
+	start = time.Now()
 	commitOutput, err := nmstatectl.Commit()
+	observeApplyDuration("commit", start, err)
+	tracing.End(commitSpan, err)
 	if err != nil {
+		transcript.Logf("nmstatectl commit failed: %v", err)
 		// We cannot rollback if commit fails, just return the error
 		return commitOutput, err
 	}
+	transcript.Logf("nmstatectl commit succeeded")
 
 	commandOutput := fmt.Sprintf("setOutput: %s \n", setOutput)
 	return commandOutput, nil
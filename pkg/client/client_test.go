@@ -0,0 +1,130 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
+)
+
+var _ = Describe("UpdateCurrentState", func() {
+	var (
+		cli      client.Client
+		nns      *nmstatev1beta1.NodeNetworkState
+		versions = &DependencyVersions{HandlerNmstateVersion: "2.2.0", HostNetworkManagerVersion: "1.42.0"}
+		before   = metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	)
+	BeforeEach(func() {
+		s := runtime.NewScheme()
+		Expect(nmstatev1beta1.AddToScheme(s)).To(Succeed())
+		nns = &nmstatev1beta1.NodeNetworkState{
+			ObjectMeta: metav1.ObjectMeta{Name: "node01"},
+			Status: shared.NodeNetworkStateStatus{
+				CurrentState: shared.NewState(`dns-resolver:
+  running: {}
+interfaces:
+- name: eth1
+  state: up
+routes:
+  running: []
+`),
+				LastChangeTime: map[string]metav1.Time{
+					"dns-resolver": before,
+					"interfaces":   before,
+					"routes":       before,
+				},
+			},
+		}
+		cli = fake.NewClientBuilder().WithScheme(s).WithStatusSubresource(nns).WithObjects(nns.DeepCopy()).Build()
+		Expect(cli.Get(context.TODO(), client.ObjectKeyFromObject(nns), nns)).To(Succeed())
+	})
+	Context("when some sections change", func() {
+		BeforeEach(func() {
+			Expect(UpdateCurrentState(context.TODO(), cli, cli, nns, shared.NewState(`dns-resolver:
+  running: {}
+interfaces:
+- name: eth1
+  state: down
+ovn: {}
+`), versions)).To(Succeed())
+		})
+		It("should update the lastChangeTime of the changed sections only", func() {
+			updatedNNS := &nmstatev1beta1.NodeNetworkState{}
+			Expect(cli.Get(context.TODO(), client.ObjectKeyFromObject(nns), updatedNNS)).To(Succeed())
+			Expect(updatedNNS.Status.LastChangeTime).To(HaveKeyWithValue("dns-resolver", before))
+			Expect(updatedNNS.Status.LastChangeTime["interfaces"].After(before.Time)).To(BeTrue())
+			Expect(updatedNNS.Status.LastChangeTime).To(HaveKey("ovn"))
+			Expect(updatedNNS.Status.LastChangeTime).ToNot(HaveKey("routes"))
+		})
+		It("should report the versions", func() {
+			updatedNNS := &nmstatev1beta1.NodeNetworkState{}
+			Expect(cli.Get(context.TODO(), client.ObjectKeyFromObject(nns), updatedNNS)).To(Succeed())
+			Expect(updatedNNS.Status.HandlerNmstateVersion).To(Equal("2.2.0"))
+			Expect(updatedNNS.Status.HostNetworkManagerVersion).To(Equal("1.42.0"))
+		})
+	})
+	Context("when the NodeNetworkState is outdated", func() {
+		BeforeEach(func() {
+			outdatedNNS := nns.DeepCopy()
+			nns.Status.HandlerNmstateVersion = "2.1.0"
+			Expect(cli.Status().Update(context.TODO(), nns)).To(Succeed())
+			nns = outdatedNNS
+		})
+		It("should retry the patch with the NodeNetworkState read from the API server", func() {
+			// The cache keeps returning the outdated NodeNetworkState
+			outdatedNNS := nns.DeepCopy()
+			cachedCli := interceptor.NewClient(cli.(client.WithWatch), interceptor.Funcs{
+				Get: func(_ context.Context, _ client.WithWatch, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					outdatedNNS.DeepCopyInto(obj.(*nmstatev1beta1.NodeNetworkState))
+					return nil
+				},
+			})
+			Expect(UpdateCurrentState(context.TODO(), cachedCli, cli, nns, shared.NewState(`interfaces: []
+`), versions)).To(Succeed())
+			updatedNNS := &nmstatev1beta1.NodeNetworkState{}
+			Expect(cli.Get(context.TODO(), client.ObjectKeyFromObject(nns), updatedNNS)).To(Succeed())
+			Expect(updatedNNS.Status.CurrentState).To(MatchYAML(shared.NewState("interfaces: []\n")))
+			Expect(updatedNNS.Status.LastChangeTime).To(HaveLen(1))
+		})
+	})
+})
+
+var _ = Describe("IsRollback", func() {
+	It("should detect rollback errors wrapped by the callers", func() {
+		err := errors.Wrap(RollbackError{errors.New("rolling back desired state configuration: probes failed")}, "apply failed")
+		Expect(IsRollback(err)).To(BeTrue())
+	})
+	It("should not detect other errors", func() {
+		Expect(IsRollback(errors.New("commit failed"))).To(BeFalse())
+		Expect(IsRollback(nil)).To(BeFalse())
+	})
+})
@@ -0,0 +1,173 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	yaml "sigs.k8s.io/yaml"
+
+	"github.com/nmstate/kubernetes-nmstate/api/names"
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+	"github.com/nmstate/kubernetes-nmstate/pkg/truncate"
+)
+
+// NodeNetworkStateChangeCause is what triggered a NodeNetworkState change
+type NodeNetworkStateChangeCause string
+
+const (
+	// NodeNetworkStateChangeCausePolicy is a change reported after applying a
+	// NodeNetworkConfigurationPolicy
+	NodeNetworkStateChangeCausePolicy NodeNetworkStateChangeCause = "NNCPApply"
+	// NodeNetworkStateChangeCauseExternal is a change done out of band, detected
+	// by the periodic NodeNetworkState refresh
+	NodeNetworkStateChangeCauseExternal NodeNetworkStateChangeCause = "ExternalChange"
+	// NodeNetworkStateChangeCausePeriodicRefresh is a change detected at the
+	// first refresh after the handler starts, so it cannot be attributed
+	NodeNetworkStateChangeCausePeriodicRefresh NodeNetworkStateChangeCause = "PeriodicRefresh"
+)
+
+const (
+	// maxNodeNetworkStateChangeDiffSize keeps a full history far from the
+	// ConfigMap size limit
+	maxNodeNetworkStateChangeDiffSize = 16 * 1024
+	truncatedDiffSuffix               = "\n[diff truncated]\n"
+	// nodeNetworkStateHistoryKeyFormat is a valid ConfigMap key that sorts
+	// lexicographically by time, it is used by the enactment transcripts too
+	nodeNetworkStateHistoryKeyFormat = "20060102T150405.000000000Z"
+)
+
+// NodeNetworkStateChange is a NodeNetworkState history entry
+type NodeNetworkStateChange struct {
+	Timestamp metav1.Time                 `json:"timestamp"`
+	Cause     NodeNetworkStateChangeCause `json:"cause"`
+	// Policy is the applied NodeNetworkConfigurationPolicy if known
+	Policy string `json:"policy,omitempty"`
+	// Sections are the changed top level sections of the state
+	Sections []string `json:"sections,omitempty"`
+	// Diff is the unified diff between the previous and the current state
+	Diff string `json:"diff"`
+}
+
+// NodeNetworkStateHistoryName returns the name of the ConfigMap storing the
+// node NodeNetworkState history
+func NodeNetworkStateHistoryName(nodeName string) string {
+	return nodeName + "-nns-history"
+}
+
+// RecordNodeNetworkStateChange adds the change to the node history ConfigMap
+// at the namespace and drops the entries beyond the history size or TTL.
+func RecordNodeNetworkStateChange(
+	ctx context.Context,
+	cli client.Client,
+	node *corev1.Node,
+	namespace string,
+	history shared.NodeNetworkStateHistory,
+	change NodeNetworkStateChange,
+) error {
+	change.Diff = truncate.Head(change.Diff, maxNodeNetworkStateChangeDiffSize, truncatedDiffSuffix)
+	entry, err := yaml.Marshal(change)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling NodeNetworkState change")
+	}
+	entryKey := change.Timestamp.UTC().Format(nodeNetworkStateHistoryKeyFormat)
+
+	key := types.NamespacedName{Namespace: namespace, Name: NodeNetworkStateHistoryName(node.Name)}
+	return retry.OnError(retry.DefaultRetry, isConflictOrAlreadyExists, func() error {
+		historyConfigMap := &corev1.ConfigMap{}
+		err := cli.Get(ctx, key, historyConfigMap)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return errors.Wrap(err, "failed getting NodeNetworkState history")
+			}
+			historyConfigMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            key.Name,
+					Namespace:       key.Namespace,
+					OwnerReferences: []metav1.OwnerReference{{Name: node.Name, Kind: "Node", APIVersion: "v1", UID: node.UID}},
+					Labels:          historyLabels(node),
+				},
+				Data: map[string]string{entryKey: string(entry)},
+			}
+			return cli.Create(ctx, historyConfigMap)
+		}
+		// Label the histories created before the history label
+		if historyConfigMap.Labels == nil {
+			historyConfigMap.Labels = map[string]string{}
+		}
+		for key, value := range historyLabels(node) {
+			historyConfigMap.Labels[key] = value
+		}
+		if historyConfigMap.Data == nil {
+			historyConfigMap.Data = map[string]string{}
+		}
+		historyConfigMap.Data[entryKey] = string(entry)
+		pruneTimestampedEntries(historyConfigMap.Data, history.Size, history.TTL, change.Timestamp.Time)
+		return cli.Update(ctx, historyConfigMap)
+	})
+}
+
+// isConflictOrAlreadyExists retries the ConfigMap creation too, the cached
+// Get may not see the one created concurrently yet.
+func isConflictOrAlreadyExists(err error) bool {
+	return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+}
+
+func historyLabels(node *corev1.Node) map[string]string {
+	return names.IncludeRelationshipLabels(map[string]string{
+		names.NodeLabelKey:                  node.Name,
+		shared.NodeNetworkStateHistoryLabel: "true",
+	})
+}
+
+// pruneTimestampedEntries removes the entries older than the TTL and the
+// oldest ones beyond the size.
+func pruneTimestampedEntries(entries map[string]string, size int32, ttl *metav1.Duration, now time.Time) {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if ttl != nil && ttl.Duration > 0 {
+		expiration := now.Add(-ttl.Duration)
+		for len(keys) > 0 {
+			timestamp, err := time.Parse(nodeNetworkStateHistoryKeyFormat, keys[0])
+			// Unknown keys are not ours, drop them too
+			if err == nil && !timestamp.Before(expiration) {
+				break
+			}
+			delete(entries, keys[0])
+			keys = keys[1:]
+		}
+	}
+
+	for size > 0 && len(keys) > int(size) {
+		delete(entries, keys[0])
+		keys = keys[1:]
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	yaml "sigs.k8s.io/yaml"
+
+	"github.com/nmstate/kubernetes-nmstate/api/names"
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+var _ = Describe("RecordNodeNetworkStateChange", func() {
+	var (
+		cli        client.Client
+		node       = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node01", UID: "12345"}}
+		historyKey = types.NamespacedName{Namespace: "nmstate", Name: "node01-nns-history"}
+		now        = time.Date(2026, 3, 12, 3, 12, 0, 0, time.UTC)
+		record     = func(history shared.NodeNetworkStateHistory, timestamp time.Time, diff string) {
+			ExpectWithOffset(1, RecordNodeNetworkStateChange(context.TODO(), cli, node, "nmstate", history, NodeNetworkStateChange{
+				Timestamp: metav1.NewTime(timestamp),
+				Cause:     NodeNetworkStateChangeCauseExternal,
+				Diff:      diff,
+			})).To(Succeed())
+		}
+		readHistory = func() map[string]string {
+			historyConfigMap := corev1.ConfigMap{}
+			ExpectWithOffset(1, cli.Get(context.TODO(), historyKey, &historyConfigMap)).To(Succeed())
+			return historyConfigMap.Data
+		}
+	)
+	BeforeEach(func() {
+		cli = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	})
+	It("should create the history owned by the node", func() {
+		record(shared.NodeNetworkStateHistory{Size: 5}, now, "diff1")
+		historyConfigMap := corev1.ConfigMap{}
+		Expect(cli.Get(context.TODO(), historyKey, &historyConfigMap)).To(Succeed())
+		Expect(historyConfigMap.OwnerReferences).To(ConsistOf(
+			metav1.OwnerReference{Name: "node01", Kind: "Node", APIVersion: "v1", UID: "12345"}))
+		Expect(historyConfigMap.Labels).To(HaveKeyWithValue(names.NodeLabelKey, "node01"))
+		Expect(historyConfigMap.Labels).To(HaveKeyWithValue(shared.NodeNetworkStateHistoryLabel, "true"))
+
+		change := NodeNetworkStateChange{}
+		Expect(yaml.Unmarshal([]byte(historyConfigMap.Data["20260312T031200.000000000Z"]), &change)).To(Succeed())
+		Expect(change.Cause).To(Equal(NodeNetworkStateChangeCauseExternal))
+		Expect(change.Diff).To(Equal("diff1"))
+	})
+	It("should drop the oldest changes beyond the size", func() {
+		history := shared.NodeNetworkStateHistory{Size: 2}
+		record(history, now, "diff1")
+		record(history, now.Add(time.Minute), "diff2")
+		record(history, now.Add(2*time.Minute), "diff3")
+		Expect(readHistory()).To(HaveLen(2))
+		Expect(readHistory()).To(HaveKey("20260312T031400.000000000Z"))
+		Expect(readHistory()).ToNot(HaveKey("20260312T031200.000000000Z"))
+	})
+	It("should drop the changes older than the TTL", func() {
+		history := shared.NodeNetworkStateHistory{Size: 10, TTL: &metav1.Duration{Duration: time.Hour}}
+		record(history, now, "diff1")
+		record(history, now.Add(30*time.Minute), "diff2")
+		record(history, now.Add(90*time.Minute), "diff3")
+		Expect(readHistory()).To(HaveLen(2))
+		Expect(readHistory()).ToNot(HaveKey("20260312T031200.000000000Z"))
+	})
+	It("should truncate big diffs", func() {
+		record(shared.NodeNetworkStateHistory{Size: 5}, now, strings.Repeat("a", 2*maxNodeNetworkStateChangeDiffSize))
+		change := NodeNetworkStateChange{}
+		Expect(yaml.Unmarshal([]byte(readHistory()["20260312T031200.000000000Z"]), &change)).To(Succeed())
+		Expect(change.Diff).To(HaveLen(maxNodeNetworkStateChangeDiffSize))
+		Expect(change.Diff).To(HaveSuffix(truncatedDiffSuffix))
+	})
+	It("should truncate big diffs on a rune boundary", func() {
+		record(shared.NodeNetworkStateHistory{Size: 5}, now, strings.Repeat("€", maxNodeNetworkStateChangeDiffSize))
+		change := NodeNetworkStateChange{}
+		Expect(yaml.Unmarshal([]byte(readHistory()["20260312T031200.000000000Z"]), &change)).To(Succeed())
+		Expect(len(change.Diff)).To(BeNumerically("<=", maxNodeNetworkStateChangeDiffSize))
+		Expect(utf8.ValidString(change.Diff)).To(BeTrue())
+		Expect(change.Diff).To(HaveSuffix(truncatedDiffSuffix))
+	})
+	It("should add the change to a history created concurrently", func() {
+		record(shared.NodeNetworkStateHistory{Size: 5}, now, "diff1")
+		// The cache does not see the history created by the other writer yet
+		staleGets := 1
+		cli = interceptor.NewClient(cli.(client.WithWatch), interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if staleGets > 0 {
+					staleGets--
+					return apierrors.NewNotFound(corev1.Resource("configmaps"), key.Name)
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		})
+		record(shared.NodeNetworkStateHistory{Size: 5}, now.Add(time.Minute), "diff2")
+		Expect(readHistory()).To(HaveLen(2))
+	})
+})
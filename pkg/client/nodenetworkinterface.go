@@ -0,0 +1,144 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nmstate/kubernetes-nmstate/api/names"
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
+	"github.com/nmstate/kubernetes-nmstate/pkg/state"
+)
+
+const maxNodeNetworkInterfaceNameLength = 253
+
+var (
+	invalidNameCharacters = regexp.MustCompile(`[^a-z0-9.-]`)
+	// a DNS subdomain label cannot start or end with "-" nor be empty
+	invalidLabelSeparators = regexp.MustCompile(`-*\.[-.]*`)
+)
+
+// NodeNetworkInterfaceName returns the NodeNetworkInterface name for a node
+// interface, the type is part of it since some interfaces share name (for
+// example ovs-bridge and ovs-interface).
+func NodeNetworkInterfaceName(nodeName string, iface state.InterfaceState) string {
+	name := fmt.Sprintf("%s.%s.%s", nodeName, iface.Name, iface.Type)
+	sanitizedName := invalidNameCharacters.ReplaceAllString(strings.ToLower(name), "-")
+	sanitizedName = strings.Trim(invalidLabelSeparators.ReplaceAllString(sanitizedName, "."), "-.")
+	if sanitizedName == name && len(name) <= maxNodeNetworkInterfaceNameLength {
+		return name
+	}
+
+	// Add a hash of the original name so sanitized names do not collide
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(name)))[:8]
+	if len(sanitizedName) > maxNodeNetworkInterfaceNameLength-len(hash)-1 {
+		sanitizedName = strings.TrimRight(sanitizedName[:maxNodeNetworkInterfaceNameLength-len(hash)-1], "-.")
+	}
+	return sanitizedName + "-" + hash
+}
+
+// SyncNodeNetworkInterfaces creates, updates or deletes the node
+// NodeNetworkInterfaces so they match the interfaces, only the changed ones are
+// updated.
+func SyncNodeNetworkInterfaces(ctx context.Context, cli client.Client, node *corev1.Node, interfaces []state.InterfaceState) error {
+	existingNNIs := nmstatev1beta1.NodeNetworkInterfaceList{}
+	err := cli.List(ctx, &existingNNIs, client.MatchingLabels{shared.NodeNetworkInterfaceNodeLabel: node.Name})
+	if err != nil {
+		return errors.Wrap(err, "failed listing NodeNetworkInterfaces")
+	}
+	existingNNIsByName := map[string]*nmstatev1beta1.NodeNetworkInterface{}
+	for i := range existingNNIs.Items {
+		existingNNIsByName[existingNNIs.Items[i].Name] = &existingNNIs.Items[i]
+	}
+
+	for _, iface := range interfaces {
+		nniName := NodeNetworkInterfaceName(node.Name, iface)
+		nni, exists := existingNNIsByName[nniName]
+		delete(existingNNIsByName, nniName)
+		if exists && nni.Status.CurrentState.String() == iface.State.String() {
+			continue
+		}
+		if !exists {
+			nni, err = initializeNodeNetworkInterface(ctx, cli, node, nniName)
+			if err != nil {
+				return err
+			}
+		}
+		nni.Status.NodeName = node.Name
+		nni.Status.InterfaceName = iface.Name
+		nni.Status.InterfaceType = iface.Type
+		nni.Status.CurrentState = iface.State
+		nni.Status.LastSuccessfulUpdateTime = metav1.Time{Time: time.Now()}
+		if err = cli.Status().Update(ctx, nni); err != nil {
+			return errors.Wrapf(err, "failed updating NodeNetworkInterface %s", nniName)
+		}
+	}
+
+	for _, staleNNI := range existingNNIsByName {
+		if err = cli.Delete(ctx, staleNNI); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed deleting stale NodeNetworkInterface %s", staleNNI.Name)
+		}
+	}
+	return nil
+}
+
+// DeleteNodeNetworkInterfaces removes all the node NodeNetworkInterfaces, it is
+// used when the state layout is back to aggregate.
+func DeleteNodeNetworkInterfaces(ctx context.Context, cli client.Client, nodeName string) error {
+	err := cli.DeleteAllOf(ctx, &nmstatev1beta1.NodeNetworkInterface{},
+		client.MatchingLabels{shared.NodeNetworkInterfaceNodeLabel: nodeName})
+	// The CRD may be missing if the cluster was never configured with a per interface layout
+	if err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return errors.Wrap(err, "failed deleting NodeNetworkInterfaces")
+	}
+	return nil
+}
+
+func initializeNodeNetworkInterface(
+	ctx context.Context,
+	cli client.Client,
+	node *corev1.Node,
+	name string,
+) (*nmstatev1beta1.NodeNetworkInterface, error) {
+	nni := nmstatev1beta1.NodeNetworkInterface{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			OwnerReferences: []metav1.OwnerReference{{Name: node.Name, Kind: "Node", APIVersion: "v1", UID: node.UID}},
+			Labels: names.IncludeRelationshipLabels(map[string]string{
+				shared.NodeNetworkInterfaceNodeLabel: node.Name,
+			}),
+		},
+	}
+	if err := cli.Create(ctx, &nni); err != nil {
+		return nil, errors.Wrapf(err, "failed creating NodeNetworkInterface %s", name)
+	}
+	return &nni, nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/nmstate/kubernetes-nmstate/pkg/state"
+)
+
+var _ = Describe("NodeNetworkInterfaceName", func() {
+	DescribeTable("should generate a valid name",
+		func(nodeName, ifaceName, ifaceType, expectedName string) {
+			Expect(NodeNetworkInterfaceName(nodeName, state.InterfaceState{Name: ifaceName, Type: ifaceType})).To(Equal(expectedName))
+		},
+		Entry("with a valid interface name", "node01", "eth1", "ethernet", "node01.eth1.ethernet"),
+		Entry("with a vlan interface name", "node01", "eth1.100", "vlan", "node01.eth1.100.vlan"),
+		Entry("with upper case and invalid characters", "node01", "Eth_1", "ethernet", "node01.eth-1.ethernet-96943ef6"),
+		Entry("with a label starting with a dash", "node01", "-eth1", "ethernet", "node01.eth1.ethernet-264292a9"),
+	)
+	It("should not collide when sanitizing", func() {
+		Expect(NodeNetworkInterfaceName("node01", state.InterfaceState{Name: "eth_1", Type: "ethernet"})).ToNot(
+			Equal(NodeNetworkInterfaceName("node01", state.InterfaceState{Name: "eth@1", Type: "ethernet"})))
+	})
+	It("should truncate too long names", func() {
+		name := NodeNetworkInterfaceName(strings.Repeat("n", 250), state.InterfaceState{Name: "eth1", Type: "ethernet"})
+		Expect(len(name)).To(BeNumerically("<=", 253))
+	})
+})
@@ -0,0 +1,169 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nmstate/kubernetes-nmstate/api/names"
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
+	"github.com/nmstate/kubernetes-nmstate/pkg/state"
+	"github.com/nmstate/kubernetes-nmstate/pkg/truncate"
+)
+
+const (
+	// DefaultEnactmentTranscriptMaxBytes caps an attempt transcript if
+	// the NMState does not configure it
+	DefaultEnactmentTranscriptMaxBytes = 64 * 1024
+	// maxEnactmentTranscriptsSize keeps all the attempts of an enactment far
+	// from the ConfigMap size limit
+	maxEnactmentTranscriptsSize = 768 * 1024
+	truncatedTranscriptPrefix   = "[transcript truncated]\n"
+	transcriptTimeFormat        = "2006-01-02T15:04:05.000Z07:00"
+)
+
+// Transcript collects the apply, probes and rollback steps of an enactment
+// attempt, including the nmstatectl logs. It is an io.Writer so it can
+// receive the nmstatectl stderr.
+type Transcript struct {
+	lock   sync.Mutex
+	buffer bytes.Buffer
+}
+
+// Logf adds a timestamped line to the transcript, it does nothing on a nil
+// transcript.
+func (t *Transcript) Logf(format string, args ...any) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	fmt.Fprintf(&t.buffer, "%s %s\n", time.Now().UTC().Format(transcriptTimeFormat), fmt.Sprintf(format, args...))
+}
+
+func (t *Transcript) Write(p []byte) (int, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.buffer.Write(p)
+}
+
+// String returns the transcript with the secrets redacted
+func (t *Transcript) String() string {
+	if t == nil {
+		return ""
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return state.RedactLog(t.buffer.String())
+}
+
+// EnactmentTranscriptName returns the name of the ConfigMap storing the
+// attempt transcripts of the enactment
+func EnactmentTranscriptName(enactmentName string) string {
+	return enactmentName + "-transcript"
+}
+
+// RecordEnactmentTranscript adds the attempt transcript to the enactment
+// transcripts ConfigMap at the namespace and drops the attempts beyond the
+// size, the TTL or the ConfigMap size limit. The ConfigMap is owned by the
+// enactment so it is removed with it.
+func RecordEnactmentTranscript(
+	ctx context.Context,
+	cli client.Client,
+	enactment *nmstatev1beta1.NodeNetworkConfigurationEnactment,
+	namespace string,
+	transcripts shared.EnactmentTranscripts,
+	timestamp time.Time,
+	transcript *Transcript,
+) error {
+	maxBytes := int(transcripts.MaxBytes)
+	if maxBytes <= 0 {
+		maxBytes = DefaultEnactmentTranscriptMaxBytes
+	}
+	// The end of the transcript is kept since it has the failure
+	attempt := truncate.Tail(transcript.String(), maxBytes, truncatedTranscriptPrefix)
+	attemptKey := timestamp.UTC().Format(nodeNetworkStateHistoryKeyFormat)
+
+	key := types.NamespacedName{Namespace: namespace, Name: EnactmentTranscriptName(enactment.Name)}
+	return retry.OnError(retry.DefaultRetry, isConflictOrAlreadyExists, func() error {
+		transcriptConfigMap := &corev1.ConfigMap{}
+		err := cli.Get(ctx, key, transcriptConfigMap)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return errors.Wrap(err, "failed getting enactment transcripts")
+			}
+			transcriptConfigMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+					OwnerReferences: []metav1.OwnerReference{{
+						Name:       enactment.Name,
+						Kind:       "NodeNetworkConfigurationEnactment",
+						APIVersion: nmstatev1beta1.GroupVersion.String(),
+						UID:        enactment.UID,
+					}},
+					Labels: names.IncludeRelationshipLabels(map[string]string{
+						shared.EnactmentNodeLabel:   enactment.Labels[shared.EnactmentNodeLabel],
+						shared.EnactmentPolicyLabel: enactment.Labels[shared.EnactmentPolicyLabel],
+					}),
+				},
+				Data: map[string]string{attemptKey: attempt},
+			}
+			return cli.Create(ctx, transcriptConfigMap)
+		}
+		if transcriptConfigMap.Data == nil {
+			transcriptConfigMap.Data = map[string]string{}
+		}
+		transcriptConfigMap.Data[attemptKey] = attempt
+		pruneTimestampedEntries(transcriptConfigMap.Data, transcripts.Size, transcripts.TTL, timestamp)
+		pruneOversizedEntries(transcriptConfigMap.Data, maxEnactmentTranscriptsSize)
+		return cli.Update(ctx, transcriptConfigMap)
+	})
+}
+
+// pruneOversizedEntries removes the oldest entries until the entries fit
+// at the size, the newest one is always kept.
+func pruneOversizedEntries(entries map[string]string, maxSize int) {
+	keys := make([]string, 0, len(entries))
+	size := 0
+	for key, value := range entries {
+		keys = append(keys, key)
+		size += len(key) + len(value)
+	}
+	sort.Strings(keys)
+
+	for len(keys) > 1 && size > maxSize {
+		size -= len(keys[0]) + len(entries[keys[0]])
+		delete(entries, keys[0])
+		keys = keys[1:]
+	}
+}
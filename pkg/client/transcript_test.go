@@ -0,0 +1,115 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
+)
+
+var _ = Describe("RecordEnactmentTranscript", func() {
+	var (
+		cli       client.Client
+		enactment = &nmstatev1beta1.NodeNetworkConfigurationEnactment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node01.policy1",
+				UID:  "12345",
+				Labels: map[string]string{
+					shared.EnactmentNodeLabel:   "node01",
+					shared.EnactmentPolicyLabel: "policy1",
+				},
+			},
+		}
+		transcriptKey = types.NamespacedName{Namespace: "nmstate", Name: "node01.policy1-transcript"}
+		now           = time.Date(2026, 3, 12, 3, 12, 0, 0, time.UTC)
+		record        = func(transcripts shared.EnactmentTranscripts, timestamp time.Time, content string) {
+			transcript := &Transcript{}
+			_, err := transcript.Write([]byte(content))
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			ExpectWithOffset(1, RecordEnactmentTranscript(
+				context.TODO(), cli, enactment, "nmstate", transcripts, timestamp, transcript)).To(Succeed())
+		}
+		readTranscripts = func() map[string]string {
+			transcriptConfigMap := corev1.ConfigMap{}
+			ExpectWithOffset(1, cli.Get(context.TODO(), transcriptKey, &transcriptConfigMap)).To(Succeed())
+			return transcriptConfigMap.Data
+		}
+	)
+	BeforeEach(func() {
+		cli = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	})
+	It("should create the transcripts owned by the enactment", func() {
+		record(shared.EnactmentTranscripts{Size: 3}, now, "attempt1")
+		transcriptConfigMap := corev1.ConfigMap{}
+		Expect(cli.Get(context.TODO(), transcriptKey, &transcriptConfigMap)).To(Succeed())
+		Expect(transcriptConfigMap.OwnerReferences).To(ConsistOf(metav1.OwnerReference{
+			Name:       "node01.policy1",
+			Kind:       "NodeNetworkConfigurationEnactment",
+			APIVersion: nmstatev1beta1.GroupVersion.String(),
+			UID:        "12345",
+		}))
+		Expect(transcriptConfigMap.Labels).To(HaveKeyWithValue(shared.EnactmentNodeLabel, "node01"))
+		Expect(transcriptConfigMap.Labels).To(HaveKeyWithValue(shared.EnactmentPolicyLabel, "policy1"))
+		Expect(transcriptConfigMap.Data).To(HaveKeyWithValue("20260312T031200.000000000Z", "attempt1"))
+	})
+	It("should drop the oldest attempts beyond the size or the TTL", func() {
+		transcripts := shared.EnactmentTranscripts{Size: 2, TTL: &metav1.Duration{Duration: time.Hour}}
+		record(transcripts, now, "attempt1")
+		record(transcripts, now.Add(time.Minute), "attempt2")
+		record(transcripts, now.Add(2*time.Minute), "attempt3")
+		Expect(readTranscripts()).To(HaveLen(2))
+		Expect(readTranscripts()).ToNot(HaveKey("20260312T031200.000000000Z"))
+		record(transcripts, now.Add(63*time.Minute), "attempt4")
+		Expect(readTranscripts()).To(HaveLen(1))
+		Expect(readTranscripts()).To(HaveKey("20260312T041500.000000000Z"))
+	})
+	It("should keep the end of big attempts", func() {
+		record(shared.EnactmentTranscripts{Size: 3, MaxBytes: 1024}, now, strings.Repeat("a", 2048)+"failure")
+		attempt := readTranscripts()["20260312T031200.000000000Z"]
+		Expect(attempt).To(HaveLen(1024))
+		Expect(attempt).To(HavePrefix(truncatedTranscriptPrefix))
+		Expect(attempt).To(HaveSuffix("failure"))
+	})
+	It("should keep the end of big attempts on a rune boundary", func() {
+		record(shared.EnactmentTranscripts{Size: 3, MaxBytes: 1024}, now, strings.Repeat("€", 1024)+"failure")
+		attempt := readTranscripts()["20260312T031200.000000000Z"]
+		Expect(len(attempt)).To(BeNumerically("<=", 1024))
+		Expect(utf8.ValidString(attempt)).To(BeTrue())
+		Expect(attempt).To(HavePrefix(truncatedTranscriptPrefix))
+		Expect(attempt).To(HaveSuffix("failure"))
+	})
+	It("should redact the secrets", func() {
+		record(shared.EnactmentTranscripts{Size: 3}, now, `WireguardConfig { private_key: Some("secret") }`)
+		Expect(readTranscripts()["20260312T031200.000000000Z"]).ToNot(ContainSubstring("secret"))
+	})
+})
@@ -31,6 +31,7 @@ import (
 
 	nmstate "github.com/nmstate/kubernetes-nmstate/api/shared"
 	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
+	"github.com/nmstate/kubernetes-nmstate/pkg/state"
 )
 
 var (
@@ -58,6 +59,13 @@ func Update(
 
 		statusSetter(&instance.Status)
 
+		// The desired state is kept in memory by the handler to apply it, the
+		// stored one is only informative so secrets are not exposed there.
+		instance.Status.DesiredState, err = state.Redact(instance.Status.DesiredState)
+		if err != nil {
+			return errors.Wrap(err, "redacting enactment desired state failed")
+		}
+
 		logger.Info(fmt.Sprintf("status: %+v", instance.Status))
 
 		return cli.Status().Update(ctx, instance)
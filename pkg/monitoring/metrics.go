@@ -48,6 +48,88 @@ var (
 		Help: "Number of NodeNetworkConfigurationEnactments labeled by node and status condition",
 	}
 
+	InterfaceOperStateOpts = prometheus.GaugeOpts{
+		Name: "kubernetes_nmstate_interface_oper_state",
+		Help: "Whether the network interface is up (1) or not (0) labeled by node, interface and type",
+	}
+
+	InterfaceMTUOpts = prometheus.GaugeOpts{
+		Name: "kubernetes_nmstate_interface_mtu_bytes",
+		Help: "MTU of the network interface labeled by node, interface and type",
+	}
+
+	InterfaceSpeedOpts = prometheus.GaugeOpts{
+		Name: "kubernetes_nmstate_interface_speed_bytes",
+		Help: "Speed in bytes per second of the ethernet interface labeled by node, interface and type",
+	}
+
+	BondPortsOpts = prometheus.GaugeOpts{
+		Name: "kubernetes_nmstate_bond_ports",
+		Help: "Number of bond ports labeled by node, interface and state (active/inactive)",
+	}
+
+	InterfaceLLDPNeighborsOpts = prometheus.GaugeOpts{
+		Name: "kubernetes_nmstate_interface_lldp_neighbors",
+		Help: "Number of LLDP neighbors of the network interface with LLDP enabled labeled by node, interface and type",
+	}
+
+	InterfaceMetricsDroppedOpts = prometheus.GaugeOpts{
+		Name: "kubernetes_nmstate_interface_metrics_dropped",
+		Help: "Number of network interfaces without per interface metrics because of the cardinality limit labeled by node",
+	}
+
+	HealthConditionsOpts = prometheus.GaugeOpts{
+		Name: "kubernetes_nmstate_health_conditions",
+		Help: "Whether the NodeNetworkState health condition is true (1) or not (0) labeled by node and condition",
+	}
+
+	StateRefreshTimestampOpts = prometheus.GaugeOpts{
+		Name: "kubernetes_nmstate_state_refresh_timestamp_seconds",
+		Help: "Unix time of the last node network state refresh done by the handler labeled by node",
+	}
+
+	PolicySlotPendingOpts = prometheus.GaugeOpts{
+		Name: "kubernetes_nmstate_policy_slot_pending",
+		Help: "Whether the node waits (1) or not (0) for a NodeNetworkConfigurationPolicy maxUnavailable slot labeled by node and policy",
+	}
+
+	PolicySlotPendingSecondsOpts = prometheus.GaugeOpts{
+		Name: "kubernetes_nmstate_policy_slot_pending_seconds",
+		Help: "Time the node has been waiting for a NodeNetworkConfigurationPolicy maxUnavailable slot labeled by node and policy",
+	}
+
+	ApplyDurationOpts = prometheus.HistogramOpts{
+		Name:    "kubernetes_nmstate_apply_duration_seconds",
+		Help:    "Duration of the nmstatectl apply, commit and rollback operations labeled by node, operation and result",
+		Buckets: prometheus.ExponentialBuckets(0.5, 2, 10),
+	}
+
+	ProbeDurationOpts = prometheus.HistogramOpts{
+		Name:    "kubernetes_nmstate_probe_duration_seconds",
+		Help:    "Time until the probes run after applying a desired state succeed labeled by node, probe and result",
+		Buckets: prometheus.ExponentialBuckets(0.25, 2, 10),
+	}
+
+	PolicyRetriesOpts = prometheus.CounterOpts{
+		Name: "kubernetes_nmstate_policy_retries_total",
+		Help: "Number of failed NodeNetworkConfigurationPolicy applies that are retried labeled by node and policy",
+	}
+
+	PolicyRollbacksOpts = prometheus.CounterOpts{
+		Name: "kubernetes_nmstate_policy_rollbacks_total",
+		Help: "Number of NodeNetworkConfigurationPolicy applies rolled back labeled by node and policy",
+	}
+
+	PolicyAbortsOpts = prometheus.CounterOpts{
+		Name: "kubernetes_nmstate_policy_aborts_total",
+		Help: "Number of aborted NodeNetworkConfigurationPolicy enactments labeled by node and policy",
+	}
+
+	SlotConflictsOpts = prometheus.CounterOpts{
+		Name: "kubernetes_nmstate_policy_slot_conflicts_total",
+		Help: "Number of conflicts taking or renewing a NodeNetworkConfigurationPolicy maxUnavailable slot labeled by node and policy",
+	}
+
 	AppliedFeatures = prometheus.NewGaugeVec(
 		AppliedFeaturesOpts,
 		[]string{"name"},
@@ -73,15 +155,130 @@ var (
 		[]string{"node", "status"},
 	)
 
+	InterfaceOperState = prometheus.NewGaugeVec(
+		InterfaceOperStateOpts,
+		[]string{"node", "interface", "type"},
+	)
+
+	InterfaceMTU = prometheus.NewGaugeVec(
+		InterfaceMTUOpts,
+		[]string{"node", "interface", "type"},
+	)
+
+	InterfaceSpeed = prometheus.NewGaugeVec(
+		InterfaceSpeedOpts,
+		[]string{"node", "interface", "type"},
+	)
+
+	BondPorts = prometheus.NewGaugeVec(
+		BondPortsOpts,
+		[]string{"node", "interface", "state"},
+	)
+
+	InterfaceLLDPNeighbors = prometheus.NewGaugeVec(
+		InterfaceLLDPNeighborsOpts,
+		[]string{"node", "interface", "type"},
+	)
+
+	InterfaceMetricsDropped = prometheus.NewGaugeVec(
+		InterfaceMetricsDroppedOpts,
+		[]string{"node"},
+	)
+
+	HealthConditions = prometheus.NewGaugeVec(
+		HealthConditionsOpts,
+		[]string{"node", "condition"},
+	)
+
+	StateRefreshTimestamp = prometheus.NewGaugeVec(
+		StateRefreshTimestampOpts,
+		[]string{"node"},
+	)
+
+	PolicySlotPending = prometheus.NewGaugeVec(
+		PolicySlotPendingOpts,
+		[]string{"node", "policy"},
+	)
+
+	PolicySlotPendingSeconds = prometheus.NewGaugeVec(
+		PolicySlotPendingSecondsOpts,
+		[]string{"node", "policy"},
+	)
+
+	ApplyDuration = prometheus.NewHistogramVec(
+		ApplyDurationOpts,
+		[]string{"node", "operation", "result"},
+	)
+
+	ProbeDuration = prometheus.NewHistogramVec(
+		ProbeDurationOpts,
+		[]string{"node", "probe", "result"},
+	)
+
+	PolicyRetries = prometheus.NewCounterVec(
+		PolicyRetriesOpts,
+		[]string{"node", "policy"},
+	)
+
+	PolicyRollbacks = prometheus.NewCounterVec(
+		PolicyRollbacksOpts,
+		[]string{"node", "policy"},
+	)
+
+	PolicyAborts = prometheus.NewCounterVec(
+		PolicyAbortsOpts,
+		[]string{"node", "policy"},
+	)
+
+	SlotConflicts = prometheus.NewCounterVec(
+		SlotConflictsOpts,
+		[]string{"node", "policy"},
+	)
+
 	gaugeOpts = []prometheus.GaugeOpts{
 		AppliedFeaturesOpts,
 		NetworkInterfacesOpts,
 		NetworkRoutesOpts,
 		PolicyStatusOpts,
 		EnactmentStatusOpts,
+		InterfaceOperStateOpts,
+		InterfaceMTUOpts,
+		InterfaceSpeedOpts,
+		BondPortsOpts,
+		InterfaceLLDPNeighborsOpts,
+		InterfaceMetricsDroppedOpts,
+		HealthConditionsOpts,
+		StateRefreshTimestampOpts,
+		PolicySlotPendingOpts,
+		PolicySlotPendingSecondsOpts,
+	}
+
+	counterOpts = []prometheus.CounterOpts{
+		PolicyRetriesOpts,
+		PolicyRollbacksOpts,
+		PolicyAbortsOpts,
+		SlotConflictsOpts,
+	}
+
+	histogramOpts = []prometheus.HistogramOpts{
+		ApplyDurationOpts,
+		ProbeDurationOpts,
 	}
 )
 
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Result returns the result label value for an operation error
+func Result(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+	return ResultSuccess
+}
+
 func Families() []pgo.MetricFamily {
 	metricFamilies := []pgo.MetricFamily{}
 	for _, gauge := range gaugeOpts {
@@ -92,5 +289,21 @@ func Families() []pgo.MetricFamily {
 			Type: &metricTypeGauge,
 		})
 	}
+	for _, counter := range counterOpts {
+		metricTypeCounter := pgo.MetricType_COUNTER
+		metricFamilies = append(metricFamilies, pgo.MetricFamily{
+			Name: new(counter.Name),
+			Help: new(counter.Help),
+			Type: &metricTypeCounter,
+		})
+	}
+	for _, histogram := range histogramOpts {
+		metricTypeHistogram := pgo.MetricType_HISTOGRAM
+		metricFamilies = append(metricFamilies, pgo.MetricFamily{
+			Name: new(histogram.Name),
+			Help: new(histogram.Help),
+			Type: &metricTypeHistogram,
+		})
+	}
 	return metricFamilies
 }
@@ -96,17 +96,35 @@ func Show() (string, error) {
 }
 
 func Set(desiredState nmstate.State, timeout time.Duration) (string, error) {
-	var setDoneCh = make(chan struct{})
-	defer close(setDoneCh)
+	return SetWithOptions(desiredState, timeout, SetOptions{})
+}
+
+// SetOptions tune a single nmstatectl apply on top of the global debug mode
+type SetOptions struct {
+	// Verbose runs nmstatectl with verbose logging
+	Verbose bool
+	// Logs receives the nmstatectl stderr, where its logs are printed
+	Logs io.Writer
+}
 
+func SetWithOptions(desiredState nmstate.State, timeout time.Duration, options SetOptions) (string, error) {
 	args := []string{"apply"}
-	if debugMode {
+	if debugMode || options.Verbose {
 		args = append(args, "-vv")
 	}
 	args = append(args, "--no-commit", "--timeout", strconv.Itoa(int(timeout.Seconds())))
 
-	setOutput, err := nmstatectlWithInput(args, string(desiredState.Raw))
-	return setOutput, err
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	var logs io.Writer = stderr
+	if options.Logs != nil {
+		logs = io.MultiWriter(stderr, options.Logs)
+	}
+	err := nmstatectlWithInputAndOutputs(args, string(desiredState.Raw), stdout, logs)
+	if err != nil {
+		return "", fmt.Errorf("%s, %s: %w", stdout.String(), stderr.String(), err)
+	}
+	return stdout.String(), nil
 }
 
 func Commit() (string, error) {
@@ -31,6 +31,7 @@ func TestSetCommandAndDebugMode(t *testing.T) {
 	tests := []struct {
 		name         string
 		debugMode    bool
+		verbose      bool
 		expectedCmd  string
 		expectedArgs []string
 	}{
@@ -46,6 +47,13 @@ func TestSetCommandAndDebugMode(t *testing.T) {
 			expectedCmd:  "nmstatectl",
 			expectedArgs: []string{"apply", "--no-commit", "--timeout", "120"},
 		},
+		{
+			name:         "with verbose apply",
+			debugMode:    false,
+			verbose:      true,
+			expectedCmd:  "nmstatectl",
+			expectedArgs: []string{"apply", "-vv", "--no-commit", "--timeout", "120"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -71,7 +79,7 @@ func TestSetCommandAndDebugMode(t *testing.T) {
 			desiredState := nmstate.State{Raw: []byte(`{"interfaces": []}`)}
 
 			SetDebugMode(tt.debugMode)
-			_, err := Set(desiredState, timeout)
+			_, err := SetWithOptions(desiredState, timeout, SetOptions{Verbose: tt.verbose})
 			if err != nil {
 				t.Errorf("Set() failed: %v", err)
 			}
@@ -0,0 +1,393 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nmstate/kubernetes-nmstate/api/names"
+	nmstate "github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstatev1 "github.com/nmstate/kubernetes-nmstate/api/v1"
+	"github.com/nmstate/kubernetes-nmstate/pkg/monitoring"
+)
+
+const (
+	// DefaultSlotLeaseDuration is how long a maxUnavailable slot is kept without
+	// renewal, so the slots of a handler that dies are freed after it.
+	DefaultSlotLeaseDuration = 2 * time.Minute
+
+	// slotFailedGenerationAnnotation marks the slot of a node that failed to
+	// configure the policy generation, it is kept until the policy changes
+	slotFailedGenerationAnnotation = "nmstate.io/failed-policy-generation"
+)
+
+type SlotLostError struct{}
+
+func (f SlotLostError) Error() string {
+	return "maxUnavailable slot expired and was taken by another node"
+}
+
+// Slots allocates the policies maxUnavailable slots to a node. Every slot is a
+// Lease at the namespace named after the policy and the slot index, so taking
+// a free slot is an atomic create and taking an expired one an update of that
+// single Lease, nodes do not race on a shared counter.
+type Slots struct {
+	// Client writes the Leases and reads the ones held by the node, they are
+	// updated with the read resourceVersion so they have to be current
+	Client client.Client
+	// Reader scans the Leases for a free slot, usually the manager cache, the
+	// Client is used if it is not set. A stale scan only makes taking a slot
+	// conflict, the cache can lag after applying a network change.
+	Reader    client.Reader
+	Namespace string
+	NodeName  string
+	// LeaseDuration is how long a slot is kept without renewal
+	LeaseDuration time.Duration
+}
+
+// SlotLeaseName returns the name of the Lease of the policy slot index
+func SlotLeaseName(policyName string, index int) string {
+	return fmt.Sprintf("%s-slot-%d", policyName, index)
+}
+
+// slotIndex returns the index of the policy slot Lease
+func slotIndex(policyName, leaseName string) (int, bool) {
+	suffix, found := strings.CutPrefix(leaseName, policyName+"-slot-")
+	if !found {
+		return 0, false
+	}
+	index, err := strconv.Atoi(suffix)
+	return index, err == nil
+}
+
+// Acquire takes one of the policy slots for the node and returns its Lease
+// name, if the node already holds one it is renewed. The nodes that failed the
+// current policy generation keep their slots so they are unavailable too. It
+// returns MaxUnavailableLimitReachedError if there is no free slot.
+func (s *Slots) Acquire(ctx context.Context, policy *nmstatev1.NodeNetworkConfigurationPolicy, maxUnavailable int) (string, error) {
+	leases, err := s.policyLeases(ctx, s.reader(), policy.Name)
+	if err != nil {
+		return "", err
+	}
+	for i := range leases.Items {
+		if !s.isHolder(&leases.Items[i]) {
+			continue
+		}
+		// Retrying a new policy generation after failing the previous one
+		err := s.renewHeld(ctx, leases.Items[i].Name, func(lease *coordinationv1.Lease) {
+			delete(lease.Annotations, slotFailedGenerationAnnotation)
+		})
+		if !errors.As(err, &SlotLostError{}) {
+			return leases.Items[i].Name, err
+		}
+	}
+
+	now := time.Now()
+	leasesByName := map[string]*coordinationv1.Lease{}
+	for i := range leases.Items {
+		lease := &leases.Items[i]
+		leasesByName[lease.Name] = lease
+		if err := s.collect(ctx, lease, policy, maxUnavailable, now); err != nil {
+			return "", err
+		}
+	}
+	for index := 0; index < maxUnavailable; index++ {
+		leaseName := SlotLeaseName(policy.Name, index)
+		lease, exists := leasesByName[leaseName]
+		if !exists {
+			lease = s.newLease(policy, leaseName)
+			err = s.Client.Create(ctx, lease)
+		} else {
+			reclaimable, reclaimErr := s.isReclaimable(ctx, lease, policy, now)
+			if reclaimErr != nil {
+				return "", reclaimErr
+			}
+			if !reclaimable {
+				continue
+			}
+			s.hold(lease)
+			err = s.Client.Update(ctx, lease)
+		}
+		if err == nil {
+			return leaseName, nil
+		}
+		if apierrors.IsAlreadyExists(err) || apierrors.IsConflict(err) {
+			// Another node took it first, try the next one
+			monitoring.SlotConflicts.WithLabelValues(s.NodeName, policy.Name).Inc()
+			continue
+		}
+		return "", errors.Wrapf(err, "failed taking maxUnavailable slot %s", leaseName)
+	}
+	return "", MaxUnavailableLimitReachedError{}
+}
+
+// collect removes the reclaimable Lease of a slot beyond maxUnavailable, they
+// are left after lowering it.
+func (s *Slots) collect(
+	ctx context.Context,
+	lease *coordinationv1.Lease,
+	policy *nmstatev1.NodeNetworkConfigurationPolicy,
+	maxUnavailable int,
+	now time.Time,
+) error {
+	if index, ok := slotIndex(policy.Name, lease.Name); !ok || index < maxUnavailable {
+		return nil
+	}
+	reclaimable, err := s.isReclaimable(ctx, lease, policy, now)
+	if err != nil || !reclaimable {
+		return err
+	}
+	err = s.Client.Delete(ctx, lease, client.Preconditions{ResourceVersion: &lease.ResourceVersion})
+	if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+		return errors.Wrapf(err, "failed removing maxUnavailable slot %s", lease.Name)
+	}
+	return nil
+}
+
+// Fail keeps the policy slot held by the node, if any, until the policy
+// generation changes, so the node stays unavailable after failing to
+// configure it.
+func (s *Slots) Fail(ctx context.Context, policy *nmstatev1.NodeNetworkConfigurationPolicy) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		leases, err := s.policyLeases(ctx, s.Client, policy.Name)
+		if err != nil {
+			return err
+		}
+		for i := range leases.Items {
+			lease := &leases.Items[i]
+			if !s.isHolder(lease) {
+				continue
+			}
+			if lease.Annotations == nil {
+				lease.Annotations = map[string]string{}
+			}
+			lease.Annotations[slotFailedGenerationAnnotation] = strconv.FormatInt(policy.Generation, 10)
+			return s.Client.Update(ctx, lease)
+		}
+		return nil
+	})
+}
+
+// Renew extends the slot while the node is still configuring the policy, it
+// returns SlotLostError if the slot expired and another node took it.
+func (s *Slots) Renew(ctx context.Context, leaseName string) error {
+	return s.renewHeld(ctx, leaseName, func(*coordinationv1.Lease) {})
+}
+
+// renewHeld reads the Lease from the API server, so a takeover is not missed,
+// and renews it after updating it if the node still holds it.
+func (s *Slots) renewHeld(ctx context.Context, leaseName string, update func(*coordinationv1.Lease)) error {
+	lease := &coordinationv1.Lease{}
+	if err := s.Client.Get(ctx, types.NamespacedName{Namespace: s.Namespace, Name: leaseName}, lease); err != nil {
+		if apierrors.IsNotFound(err) {
+			return SlotLostError{}
+		}
+		return errors.Wrapf(err, "failed getting maxUnavailable slot %s", leaseName)
+	}
+	if !s.isHolder(lease) {
+		return SlotLostError{}
+	}
+	update(lease)
+	return s.renew(ctx, lease)
+}
+
+// Release frees the policy slot held by the node if any
+func (s *Slots) Release(ctx context.Context, policyName string) error {
+	// The Lease may be renewed meanwhile, so it is read again on conflict
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		leases, err := s.policyLeases(ctx, s.Client, policyName)
+		if err != nil {
+			return err
+		}
+		return s.release(ctx, leases)
+	})
+}
+
+// ReleaseAll frees all the slots held by the node, it is called at handler
+// start since no policy is being configured then.
+func (s *Slots) ReleaseAll(ctx context.Context) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		leases := &coordinationv1.LeaseList{}
+		err := s.Client.List(ctx, leases, client.InNamespace(s.Namespace), client.MatchingLabels{nmstate.EnactmentNodeLabel: s.NodeName})
+		if err != nil {
+			return errors.Wrap(err, "failed listing node maxUnavailable slots")
+		}
+		return s.release(ctx, leases)
+	})
+}
+
+func (s *Slots) release(ctx context.Context, leases *coordinationv1.LeaseList) error {
+	for i := range leases.Items {
+		lease := &leases.Items[i]
+		if !s.isHolder(lease) {
+			continue
+		}
+		err := s.Client.Delete(ctx, lease, client.Preconditions{ResourceVersion: &lease.ResourceVersion})
+		if apierrors.IsConflict(err) {
+			return err
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed releasing maxUnavailable slot %s", lease.Name)
+		}
+	}
+	return nil
+}
+
+func (s *Slots) policyLeases(ctx context.Context, reader client.Reader, policyName string) (*coordinationv1.LeaseList, error) {
+	leases := &coordinationv1.LeaseList{}
+	err := reader.List(ctx, leases, client.InNamespace(s.Namespace), client.MatchingLabels{nmstate.EnactmentPolicyLabel: policyName})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed listing policy maxUnavailable slots")
+	}
+	return leases, nil
+}
+
+func (s *Slots) newLease(policy *nmstatev1.NodeNetworkConfigurationPolicy, leaseName string) *coordinationv1.Lease {
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      leaseName,
+			Namespace: s.Namespace,
+			// The slots are removed with the policy
+			OwnerReferences: []metav1.OwnerReference{{
+				Name:       policy.Name,
+				Kind:       "NodeNetworkConfigurationPolicy",
+				APIVersion: nmstatev1.GroupVersion.String(),
+				UID:        policy.UID,
+			}},
+			Labels: names.IncludeRelationshipLabels(map[string]string{
+				nmstate.EnactmentPolicyLabel: policy.Name,
+			}),
+		},
+	}
+	s.hold(lease)
+	return lease
+}
+
+func (s *Slots) hold(lease *coordinationv1.Lease) {
+	now := metav1.NewMicroTime(time.Now())
+	holder := s.NodeName
+	leaseDurationSeconds := int32(s.leaseDuration().Seconds()) //nolint:gosec // a few minutes
+	lease.Spec.HolderIdentity = &holder
+	lease.Spec.LeaseDurationSeconds = &leaseDurationSeconds
+	lease.Spec.AcquireTime = &now
+	lease.Spec.RenewTime = &now
+	delete(lease.Annotations, slotFailedGenerationAnnotation)
+	if lease.Labels == nil {
+		lease.Labels = map[string]string{}
+	}
+	lease.Labels[nmstate.EnactmentNodeLabel] = s.NodeName
+}
+
+func (s *Slots) renew(ctx context.Context, lease *coordinationv1.Lease) error {
+	now := metav1.NewMicroTime(time.Now())
+	lease.Spec.RenewTime = &now
+	if err := s.Client.Update(ctx, lease); err != nil {
+		if apierrors.IsConflict(err) {
+			monitoring.SlotConflicts.WithLabelValues(s.NodeName, lease.Labels[nmstate.EnactmentPolicyLabel]).Inc()
+		}
+		return errors.Wrapf(err, "failed renewing maxUnavailable slot %s", lease.Name)
+	}
+	return nil
+}
+
+// IsConfiguring returns if the node holds the slot Lease to configure its
+// policy, the slots kept by failed nodes and the expired ones are not.
+func IsConfiguring(lease *coordinationv1.Lease, nodeName string, now time.Time) bool {
+	if _, failed := lease.Annotations[slotFailedGenerationAnnotation]; failed {
+		return false
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != nodeName || lease.Spec.RenewTime == nil {
+		return false
+	}
+	leaseDuration := DefaultSlotLeaseDuration
+	if lease.Spec.LeaseDurationSeconds != nil {
+		leaseDuration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+	return !lease.Spec.RenewTime.Add(leaseDuration).Before(now)
+}
+
+func (s *Slots) isHolder(lease *coordinationv1.Lease) bool {
+	return lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == s.NodeName
+}
+
+func (s *Slots) isExpired(lease *coordinationv1.Lease, policy *nmstatev1.NodeNetworkConfigurationPolicy, now time.Time) bool {
+	// The slot of a failed node is not renewed, it is freed when the policy changes
+	if failedGeneration, failed := lease.Annotations[slotFailedGenerationAnnotation]; failed {
+		return failedGeneration != strconv.FormatInt(policy.Generation, 10)
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == "" || lease.Spec.RenewTime == nil {
+		return true
+	}
+	leaseDuration := s.leaseDuration()
+	if lease.Spec.LeaseDurationSeconds != nil {
+		leaseDuration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+	return lease.Spec.RenewTime.Add(leaseDuration).Before(now)
+}
+
+// isReclaimable returns if the slot is expired or kept by a failed node that
+// is gone, the failed slots are not renewed.
+func (s *Slots) isReclaimable(
+	ctx context.Context,
+	lease *coordinationv1.Lease,
+	policy *nmstatev1.NodeNetworkConfigurationPolicy,
+	now time.Time,
+) (bool, error) {
+	if s.isExpired(lease, policy, now) {
+		return true, nil
+	}
+	if _, failed := lease.Annotations[slotFailedGenerationAnnotation]; !failed || lease.Spec.HolderIdentity == nil || s.isHolder(lease) {
+		return false, nil
+	}
+	// The node cache only has this node
+	err := s.Client.Get(ctx, types.NamespacedName{Name: *lease.Spec.HolderIdentity}, &corev1.Node{})
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "failed getting maxUnavailable slot %s holder node", lease.Name)
+	}
+	return false, nil
+}
+
+func (s *Slots) reader() client.Reader {
+	if s.Reader == nil {
+		return s.Client
+	}
+	return s.Reader
+}
+
+func (s *Slots) leaseDuration() time.Duration {
+	if s.LeaseDuration <= 0 {
+		return DefaultSlotLeaseDuration
+	}
+	return s.LeaseDuration
+}
@@ -0,0 +1,163 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nmstatev1 "github.com/nmstate/kubernetes-nmstate/api/v1"
+	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
+)
+
+var _ = Describe("maxUnavailable slots", func() {
+	var (
+		cli    client.Client
+		policy = &nmstatev1.NodeNetworkConfigurationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy1", UID: "12345"},
+		}
+		slotsOf = func(nodeName string) *Slots {
+			return &Slots{Client: cli, Namespace: "nmstate", NodeName: nodeName}
+		}
+		holderOf = func(leaseName string) string {
+			lease := &coordinationv1.Lease{}
+			ExpectWithOffset(1, cli.Get(context.TODO(), types.NamespacedName{Namespace: "nmstate", Name: leaseName}, lease)).To(Succeed())
+			return *lease.Spec.HolderIdentity
+		}
+		newClient = func(objs ...runtime.Object) client.Client {
+			s := scheme.Scheme
+			s.AddKnownTypes(nmstatev1beta1.GroupVersion,
+				&nmstatev1beta1.NodeNetworkConfigurationEnactment{},
+				&nmstatev1beta1.NodeNetworkConfigurationEnactmentList{},
+			)
+			return fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()
+		}
+	)
+	BeforeEach(func() {
+		cli = newClient()
+	})
+	It("should give every node its own slot up to maxUnavailable", func() {
+		Expect(slotsOf("node01").Acquire(context.TODO(), policy, 2)).To(Equal("policy1-slot-0"))
+		Expect(slotsOf("node02").Acquire(context.TODO(), policy, 2)).To(Equal("policy1-slot-1"))
+		_, err := slotsOf("node03").Acquire(context.TODO(), policy, 2)
+		Expect(err).To(MatchError(MaxUnavailableLimitReachedError{}))
+		Expect(holderOf("policy1-slot-0")).To(Equal("node01"))
+		Expect(holderOf("policy1-slot-1")).To(Equal("node02"))
+	})
+	It("should renew the slot already held by the node", func() {
+		Expect(slotsOf("node01").Acquire(context.TODO(), policy, 1)).To(Equal("policy1-slot-0"))
+		Expect(slotsOf("node01").Acquire(context.TODO(), policy, 1)).To(Equal("policy1-slot-0"))
+		Expect(slotsOf("node01").Renew(context.TODO(), "policy1-slot-0")).To(Succeed())
+		Expect(slotsOf("node02").Renew(context.TODO(), "policy1-slot-0")).To(MatchError(SlotLostError{}))
+	})
+	It("should free the slot once released", func() {
+		Expect(slotsOf("node01").Acquire(context.TODO(), policy, 1)).To(Equal("policy1-slot-0"))
+		Expect(slotsOf("node02").Release(context.TODO(), policy.Name)).To(Succeed())
+		_, err := slotsOf("node02").Acquire(context.TODO(), policy, 1)
+		Expect(err).To(MatchError(MaxUnavailableLimitReachedError{}))
+		Expect(slotsOf("node01").ReleaseAll(context.TODO())).To(Succeed())
+		Expect(slotsOf("node02").Acquire(context.TODO(), policy, 1)).To(Equal("policy1-slot-0"))
+	})
+	It("should take over the slot of a node that stopped renewing it", func() {
+		Expect(slotsOf("node01").Acquire(context.TODO(), policy, 1)).To(Equal("policy1-slot-0"))
+		lease := &coordinationv1.Lease{}
+		Expect(cli.Get(context.TODO(), types.NamespacedName{Namespace: "nmstate", Name: "policy1-slot-0"}, lease)).To(Succeed())
+		lease.Spec.RenewTime = &metav1.MicroTime{Time: time.Now().Add(-2 * DefaultSlotLeaseDuration)}
+		Expect(cli.Update(context.TODO(), lease)).To(Succeed())
+
+		Expect(slotsOf("node02").Acquire(context.TODO(), policy, 1)).To(Equal("policy1-slot-0"))
+		Expect(holderOf("policy1-slot-0")).To(Equal("node02"))
+		Expect(slotsOf("node01").Renew(context.TODO(), "policy1-slot-0")).To(MatchError(SlotLostError{}))
+	})
+	It("should keep the slots of the nodes that failed the policy until it changes", func() {
+		cli = newClient(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node01"}})
+		Expect(slotsOf("node01").Acquire(context.TODO(), policy, 1)).To(Equal("policy1-slot-0"))
+		Expect(slotsOf("node01").Fail(context.TODO(), policy)).To(Succeed())
+		lease := &coordinationv1.Lease{}
+		Expect(cli.Get(context.TODO(), types.NamespacedName{Namespace: "nmstate", Name: "policy1-slot-0"}, lease)).To(Succeed())
+		Expect(IsConfiguring(lease, "node01", time.Now())).To(BeFalse(), "the failed node is not configuring the policy")
+		lease.Spec.RenewTime = &metav1.MicroTime{Time: time.Now().Add(-2 * DefaultSlotLeaseDuration)}
+		Expect(cli.Update(context.TODO(), lease)).To(Succeed())
+
+		_, err := slotsOf("node02").Acquire(context.TODO(), policy, 1)
+		Expect(err).To(MatchError(MaxUnavailableLimitReachedError{}))
+
+		updatedPolicy := policy.DeepCopy()
+		updatedPolicy.Generation++
+		Expect(slotsOf("node02").Acquire(context.TODO(), updatedPolicy, 1)).To(Equal("policy1-slot-0"))
+		Expect(holderOf("policy1-slot-0")).To(Equal("node02"))
+	})
+	It("should reclaim the slot kept by a failed node that was removed", func() {
+		Expect(slotsOf("node01").Acquire(context.TODO(), policy, 1)).To(Equal("policy1-slot-0"))
+		Expect(slotsOf("node01").Fail(context.TODO(), policy)).To(Succeed())
+
+		Expect(slotsOf("node02").Acquire(context.TODO(), policy, 1)).To(Equal("policy1-slot-0"))
+		Expect(holderOf("policy1-slot-0")).To(Equal("node02"))
+	})
+	It("should remove the free slots beyond a lowered maxUnavailable", func() {
+		Expect(slotsOf("node01").Acquire(context.TODO(), policy, 2)).To(Equal("policy1-slot-0"))
+		Expect(slotsOf("node02").Acquire(context.TODO(), policy, 2)).To(Equal("policy1-slot-1"))
+		Expect(slotsOf("node01").Release(context.TODO(), policy.Name)).To(Succeed())
+		lease := &coordinationv1.Lease{}
+		Expect(cli.Get(context.TODO(), types.NamespacedName{Namespace: "nmstate", Name: "policy1-slot-1"}, lease)).To(Succeed())
+		lease.Spec.RenewTime = &metav1.MicroTime{Time: time.Now().Add(-2 * DefaultSlotLeaseDuration)}
+		Expect(cli.Update(context.TODO(), lease)).To(Succeed())
+
+		Expect(slotsOf("node03").Acquire(context.TODO(), policy, 1)).To(Equal("policy1-slot-0"))
+		Expect(cli.Get(context.TODO(), types.NamespacedName{Namespace: "nmstate", Name: "policy1-slot-1"}, lease)).
+			To(MatchError(apierrors.IsNotFound, "IsNotFound"))
+	})
+	It("should keep the slots beyond a lowered maxUnavailable while they are held", func() {
+		Expect(slotsOf("node01").Acquire(context.TODO(), policy, 2)).To(Equal("policy1-slot-0"))
+		Expect(slotsOf("node02").Acquire(context.TODO(), policy, 2)).To(Equal("policy1-slot-1"))
+		_, err := slotsOf("node03").Acquire(context.TODO(), policy, 1)
+		Expect(err).To(MatchError(MaxUnavailableLimitReachedError{}))
+		Expect(holderOf("policy1-slot-1")).To(Equal("node02"))
+	})
+	It("should renew and release the held slot the stale reader misses", func() {
+		Expect(slotsOf("node01").Acquire(context.TODO(), policy, 1)).To(Equal("policy1-slot-0"))
+		slots := &Slots{Client: cli, Reader: newClient(), Namespace: "nmstate", NodeName: "node01"}
+		Expect(slots.Renew(context.TODO(), "policy1-slot-0")).To(Succeed())
+		Expect(slots.Fail(context.TODO(), policy)).To(Succeed())
+		Expect(slots.Release(context.TODO(), policy.Name)).To(Succeed())
+		Expect(cli.Get(context.TODO(), types.NamespacedName{Namespace: "nmstate", Name: "policy1-slot-0"}, &coordinationv1.Lease{})).
+			To(MatchError(apierrors.IsNotFound, "IsNotFound"))
+	})
+	It("should read the slots from the reader and write them with the client", func() {
+		Expect(slotsOf("node01").Acquire(context.TODO(), policy, 1)).To(Equal("policy1-slot-0"))
+		staleReader := newClient()
+		slots := &Slots{Client: cli, Reader: staleReader, Namespace: "nmstate", NodeName: "node02"}
+		By("Creating the slot the stale reader misses conflicts")
+		_, err := slots.Acquire(context.TODO(), policy, 1)
+		Expect(err).To(MatchError(MaxUnavailableLimitReachedError{}))
+		Expect(holderOf("policy1-slot-0")).To(Equal("node01"))
+	})
+})
@@ -242,6 +242,11 @@ func update(
 		)
 
 		setPolicyStatus(policy, &policyStatus)
+		policy.Status.Nodes = nodesStatus(policy, &policyStatus, &enactments)
+		// The maxUnavailable slots are Leases, the deprecated counters are cleared
+		policy.Status.UnavailableNodeCount = 0
+		policy.Status.UnavailableNodeCountMap = nil
+		policy.Status.LastUnavailableNodeCountUpdate = nil
 
 		if err = apiWriter.Status().Update(ctx, policy); err != nil {
 			if apierrors.IsConflict(err) {
@@ -315,7 +320,6 @@ func setPolicyStatus(policy *nmstatev1.NodeNetworkConfigurationPolicy, policySta
 			policyStatus.numberOfNmstateMatchingNodes,
 		)
 		informOfNotReadyNodes(policyStatus.numberOfNotReadyNmstateMatchingNodes)
-		policy.Status.UnavailableNodeCountMap = make(map[string]int)
 		SetPolicySuccess(&policy.Status.Conditions, message)
 	}
 }
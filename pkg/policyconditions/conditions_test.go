@@ -20,7 +20,9 @@ package policyconditions
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -389,4 +391,88 @@ var _ = Describe("Policy Conditions", func() {
 			Policy: p(SetPolicyProgressing, "Policy is progressing 3/4 nodes finished"),
 		}),
 	)
+	Context("when the policy status is updated", func() {
+		var updatedPolicy *nmstatev1.NodeNetworkConfigurationPolicy
+		BeforeEach(func() {
+			failing := func(conditions *nmstate.ConditionList, _ string) {
+				enactmentconditions.SetFailedToConfigure(conditions, "error reconciling NodeNetworkConfigurationPolicy\nnmstatectl output")
+			}
+			enactments := []nmstatev1beta1.NodeNetworkConfigurationEnactment{
+				e("node1", "policy1", enactmentconditions.SetSuccess),
+				e("node2", "policy1", enactmentconditions.SetPending),
+			}
+			for i := 3; i <= maxNodeIssues+3; i++ {
+				enactments = append(enactments, e(nodeName(i), "policy1", failing))
+			}
+			scheme.Scheme.AddKnownTypes(nmstatev1beta1.GroupVersion,
+				&nmstatev1beta1.NodeNetworkConfigurationEnactment{},
+				&nmstatev1beta1.NodeNetworkConfigurationEnactmentList{},
+			)
+			scheme.Scheme.AddKnownTypes(nmstatev1.GroupVersion,
+				&nmstatev1.NodeNetworkConfigurationPolicy{},
+			)
+			objs := []runtime.Object{}
+			for i := range enactments {
+				objs = append(objs, &enactments[i])
+			}
+			nodes := newNodes(maxNodeIssues + 4)
+			for i := range nodes {
+				objs = append(objs, &nodes[i])
+			}
+			pods := newNmstatePods(maxNodeIssues + 4)
+			for i := range pods {
+				objs = append(objs, &pods[i])
+			}
+			updatedPolicy = &nmstatev1.NodeNetworkConfigurationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy1"},
+				Status: nmstate.NodeNetworkConfigurationPolicyStatus{
+					UnavailableNodeCount:    2,
+					UnavailableNodeCountMap: map[string]int{"1": 2},
+				},
+			}
+			objs = append(objs, updatedPolicy)
+
+			client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithStatusSubresource(updatedPolicy).WithRuntimeObjects(objs...).Build()
+			key := types.NamespacedName{Name: updatedPolicy.Name}
+			Expect(Update(context.TODO(), client, client, key)).To(Succeed())
+			Expect(client.Get(context.TODO(), key, updatedPolicy)).To(Succeed())
+		})
+		It("should summarize the enactments per state", func() {
+			Expect(updatedPolicy.Status.Nodes).ToNot(BeNil())
+			Expect(updatedPolicy.Status.Nodes.Matching).To(Equal(maxNodeIssues + 4))
+			Expect(updatedPolicy.Status.Nodes.Available).To(Equal(1))
+			Expect(updatedPolicy.Status.Nodes.Failing).To(Equal(maxNodeIssues + 1))
+			Expect(updatedPolicy.Status.Nodes.Pending).To(Equal(1))
+			Expect(updatedPolicy.Status.Nodes.Summary).To(Equal(fmt.Sprintf("1/%d Available, %d Failing, 1 Pending", maxNodeIssues+4, maxNodeIssues+1)))
+		})
+		It("should clear the deprecated unavailable node counters", func() {
+			Expect(updatedPolicy.Status.UnavailableNodeCount).To(BeZero())
+			Expect(updatedPolicy.Status.UnavailableNodeCountMap).To(BeEmpty())
+			Expect(updatedPolicy.Status.LastUnavailableNodeCountUpdate).To(BeNil())
+		})
+		It("should list a bounded number of node issues with short reasons, failing first", func() {
+			issues := updatedPolicy.Status.Nodes.Issues
+			Expect(issues).To(HaveLen(maxNodeIssues))
+			Expect(updatedPolicy.Status.Nodes.OmittedIssues).To(Equal(2))
+			Expect(issues[0]).To(Equal(nmstate.NodeNetworkConfigurationPolicyNodeIssue{
+				Node:   nodeName(10),
+				State:  nmstate.NodeNetworkConfigurationEnactmentConditionFailing,
+				Reason: "error reconciling NodeNetworkConfigurationPolicy",
+			}))
+			for _, issue := range issues {
+				Expect(issue.State).To(Equal(nmstate.NodeNetworkConfigurationEnactmentConditionFailing))
+			}
+		})
+	})
+})
+
+var _ = Describe("Node issue short reason", func() {
+	It("should truncate long reasons on a rune boundary", func() {
+		reason := shortReason(&nmstate.Condition{
+			Message: strings.Repeat("a", maxNodeIssueReasonLength-4) + "ñandú\nsecond line",
+		})
+		Expect(utf8.ValidString(reason)).To(BeTrue())
+		Expect(len(reason)).To(BeNumerically("<=", maxNodeIssueReasonLength))
+		Expect(reason).To(Equal(strings.Repeat("a", maxNodeIssueReasonLength-4) + "..."))
+	})
 })
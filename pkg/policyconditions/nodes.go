@@ -0,0 +1,143 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyconditions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	nmstate "github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstatev1 "github.com/nmstate/kubernetes-nmstate/api/v1"
+	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
+	"github.com/nmstate/kubernetes-nmstate/pkg/truncate"
+)
+
+const (
+	// maxNodeIssues bounds the nodes listed at status.nodes.issues so the
+	// policy status stays small at big clusters
+	maxNodeIssues = 10
+	// maxNodeIssueReasonLength bounds the enactment message copied as reason
+	maxNodeIssueReasonLength = 128
+)
+
+// issueConditionTypes are the enactment conditions reported as node issues
+// sorted by severity
+var issueConditionTypes = []nmstate.ConditionType{
+	nmstate.NodeNetworkConfigurationEnactmentConditionFailing,
+	nmstate.NodeNetworkConfigurationEnactmentConditionAborted,
+	nmstate.NodeNetworkConfigurationEnactmentConditionPending,
+}
+
+func nodesStatus(
+	policy *nmstatev1.NodeNetworkConfigurationPolicy,
+	policyStatus *policyConditionStatus,
+	enactments *nmstatev1beta1.NodeNetworkConfigurationEnactmentList,
+) *nmstate.NodeNetworkConfigurationPolicyNodesStatus {
+	count := policyStatus.enactmentsCountByCondition
+	status := &nmstate.NodeNetworkConfigurationPolicyNodesStatus{
+		Matching:    policyStatus.numberOfNmstateMatchingNodes,
+		Available:   count.Available(),
+		Failing:     count.Failed(),
+		Aborted:     count.Aborted(),
+		Pending:     count.Pending(),
+		Progressing: count.Progressing(),
+	}
+	status.Summary = nodesSummary(status)
+
+	issues := nodeIssues(policy, enactments)
+	if len(issues) > maxNodeIssues {
+		status.OmittedIssues = len(issues) - maxNodeIssues
+		issues = issues[:maxNodeIssues]
+	}
+	status.Issues = issues
+	return status
+}
+
+func nodesSummary(status *nmstate.NodeNetworkConfigurationPolicyNodesStatus) string {
+	summary := fmt.Sprintf("%d/%d Available", status.Available, status.Matching)
+	for _, count := range []struct {
+		name  string
+		value int
+	}{
+		{"Failing", status.Failing},
+		{"Aborted", status.Aborted},
+		{"Pending", status.Pending},
+		{"Progressing", status.Progressing},
+	} {
+		if count.value > 0 {
+			summary += fmt.Sprintf(", %d %s", count.value, count.name)
+		}
+	}
+	return summary
+}
+
+func nodeIssues(
+	policy *nmstatev1.NodeNetworkConfigurationPolicy,
+	enactments *nmstatev1beta1.NodeNetworkConfigurationEnactmentList,
+) []nmstate.NodeNetworkConfigurationPolicyNodeIssue {
+	issues := []nmstate.NodeNetworkConfigurationPolicyNodeIssue{}
+	severity := map[nmstate.ConditionType]int{}
+	for i, conditionType := range issueConditionTypes {
+		severity[conditionType] = i
+	}
+	for i := range enactments.Items {
+		enactment := &enactments.Items[i]
+		if enactment.Status.PolicyGeneration != policy.Generation {
+			continue
+		}
+		for _, conditionType := range issueConditionTypes {
+			condition := enactment.Status.Conditions.Find(conditionType)
+			if condition == nil || condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			issues = append(issues, nmstate.NodeNetworkConfigurationPolicyNodeIssue{
+				Node:   enactmentNodeName(policy, enactment),
+				State:  conditionType,
+				Reason: shortReason(condition),
+			})
+			break
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].State != issues[j].State {
+			return severity[issues[i].State] < severity[issues[j].State]
+		}
+		return issues[i].Node < issues[j].Node
+	})
+	return issues
+}
+
+func enactmentNodeName(policy *nmstatev1.NodeNetworkConfigurationPolicy, enactment *nmstatev1beta1.NodeNetworkConfigurationEnactment) string {
+	if nodeName, ok := enactment.Labels[nmstate.EnactmentNodeLabel]; ok {
+		return nodeName
+	}
+	return strings.TrimSuffix(enactment.Name, "."+policy.Name)
+}
+
+// shortReason keeps the first line of the condition message, since failure
+// messages contain the whole nmstate output, falling back to the reason
+func shortReason(condition *nmstate.Condition) string {
+	reason, _, _ := strings.Cut(strings.TrimSpace(condition.Message), "\n")
+	if reason == "" {
+		return string(condition.Reason)
+	}
+	return truncate.Head(reason, maxNodeIssueReasonLength, "...")
+}
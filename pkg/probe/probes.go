@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -38,7 +39,10 @@ import (
 	"github.com/tidwall/gjson"
 
 	"github.com/nmstate/kubernetes-nmstate/pkg/environment"
+	"github.com/nmstate/kubernetes-nmstate/pkg/monitoring"
 	"github.com/nmstate/kubernetes-nmstate/pkg/nmstatectl"
+	"github.com/nmstate/kubernetes-nmstate/pkg/state"
+	"github.com/nmstate/kubernetes-nmstate/pkg/tracing"
 )
 
 var (
@@ -160,7 +164,7 @@ func defaultGw(currentState gjson.Result) (Route, error) {
 		defaultGwLog := log.WithValues("path", "routes.running.next-hop-address", "table-id", mainRoutingTableID)
 		defaultGwLogDebug := defaultGwLog.V(1)
 		if defaultGwLogDebug.Enabled() {
-			defaultGwLogDebug.Info(msg, "state", currentState.String())
+			defaultGwLogDebug.Info(msg, "state", state.RedactString(currentState.String()))
 		} else {
 			defaultGwLog.Info(msg)
 		}
@@ -231,7 +235,7 @@ func runDNS(_ client.Client, timeout time.Duration) (bool, error) {
 	}
 	runningNameServers := currentStateAsGJson.Get(runningServersGJsonPath).Array()
 	if len(runningNameServers) == 0 {
-		log.Info(fmt.Sprintf("missing name servers at '%s' on %s", runningServersGJsonPath, currentStateAsGJson.String()))
+		log.Info(fmt.Sprintf("missing name servers at '%s' on %s", runningServersGJsonPath, state.RedactString(currentStateAsGJson.String())))
 		return false, nil
 	}
 	for _, runningNameServer := range runningNameServers {
@@ -309,7 +313,10 @@ func Select(ctx context.Context, cli client.Client) []Probe {
 
 // Run will run the externalConnectivityProbes and also some internal
 // kubernetes cluster connectivity and node readiness probes
-func Run(ctx context.Context, cli client.Client, probes []Probe) error {
+func Run(ctx context.Context, cli client.Client, probes []Probe) (err error) {
+	ctx, span := tracing.Start(ctx, "probe.Run")
+	defer func() { tracing.End(span, err) }()
+
 	currentState, err := nmstatectl.Show()
 	if err != nil {
 		return errors.Wrap(err, "failed to retrieve currentState at runProbes")
@@ -317,11 +324,16 @@ func Run(ctx context.Context, cli client.Client, probes []Probe) error {
 
 	for _, p := range probes {
 		log.Info(fmt.Sprintf("Running '%s' probe", p.name))
-		err = wait.PollUntilContextTimeout(ctx, time.Second, p.timeout, true /*immediate*/, p.condition(cli, p.timeout))
+		probeCtx, probeSpan := tracing.Start(ctx, "probe."+p.name, attribute.String("probe", p.name))
+		start := time.Now()
+		err = wait.PollUntilContextTimeout(probeCtx, time.Second, p.timeout, true /*immediate*/, p.condition(cli, p.timeout))
+		monitoring.ProbeDuration.WithLabelValues(environment.NodeName(), p.name, monitoring.Result(err)).
+			Observe(time.Since(start).Seconds())
+		tracing.End(probeSpan, err)
 		if err != nil {
 			return errors.Wrapf(
 				err,
-				"failed runnig probe '%s' with after network reconfiguration -> currentState: %s", p.name, currentState,
+				"failed runnig probe '%s' with after network reconfiguration -> currentState: %s", p.name, state.RedactString(currentState),
 			)
 		}
 	}
@@ -0,0 +1,207 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+// rbacGroup objects are never patched, the operator can escalate and bind
+// roles so an overlay could grant more than its ConfigMap writers hold
+const rbacGroup = "rbac.authorization.k8s.io"
+
+var rbacKinds = map[string]bool{"Role": true, "ClusterRole": true, "RoleBinding": true, "ClusterRoleBinding": true}
+
+// Overlay is a kustomize style patch of the rendered manifests
+type Overlay struct {
+	// Name identifies the overlay, it is applied in name order
+	Name string `json:"-"`
+	// Target selects the patched objects, every field set has to match. It
+	// defaults to the object of a strategic merge patch.
+	Target *OverlayTarget `json:"target,omitempty"`
+	// Patch is a strategic merge patch or, if it is a list of operations, a
+	// JSON 6902 patch
+	Patch string `json:"patch"`
+
+	jsonPatch  jsonpatch.Patch
+	mergePatch []byte
+}
+
+// OverlayTarget selects the objects patched by an overlay
+type OverlayTarget struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ParseOverlays parses and validates the overlays keyed by name, like the
+// data of a ConfigMap, and returns them in name order.
+func ParseOverlays(data map[string]string) ([]Overlay, error) {
+	overlays := []Overlay{}
+	for name, content := range data {
+		overlay, err := parseOverlay(name, content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid overlay %q: %w", name, err)
+		}
+		overlays = append(overlays, overlay)
+	}
+	sort.Slice(overlays, func(i, j int) bool { return overlays[i].Name < overlays[j].Name })
+	return overlays, nil
+}
+
+func parseOverlay(name, content string) (Overlay, error) {
+	overlay := Overlay{}
+	if err := yaml.UnmarshalStrict([]byte(content), &overlay); err != nil {
+		return overlay, err
+	}
+	overlay.Name = name
+	if strings.TrimSpace(overlay.Patch) == "" {
+		return overlay, fmt.Errorf("missing patch")
+	}
+	patch, err := yaml.YAMLToJSON([]byte(overlay.Patch))
+	if err != nil {
+		return overlay, fmt.Errorf("failed parsing patch: %w", err)
+	}
+
+	switch {
+	case strings.HasPrefix(strings.TrimSpace(string(patch)), "["):
+		if overlay.jsonPatch, err = jsonpatch.DecodePatch(patch); err != nil {
+			return overlay, fmt.Errorf("failed parsing JSON patch: %w", err)
+		}
+		if overlay.Target == nil || *overlay.Target == (OverlayTarget{}) {
+			return overlay, fmt.Errorf("a JSON patch needs a target")
+		}
+		return overlay, validateTarget(overlay.Target)
+	case !strings.HasPrefix(strings.TrimSpace(string(patch)), "{"):
+		return overlay, fmt.Errorf("the patch is neither a strategic merge patch object nor a JSON patch list")
+	}
+
+	if overlay.Target == nil {
+		object := &unstructured.Unstructured{}
+		if err := object.UnmarshalJSON(patch); err != nil {
+			return overlay, fmt.Errorf("failed parsing strategic merge patch: %w", err)
+		}
+		gvk := object.GroupVersionKind()
+		overlay.Target = &OverlayTarget{
+			Group:     gvk.Group,
+			Version:   gvk.Version,
+			Kind:      gvk.Kind,
+			Name:      object.GetName(),
+			Namespace: object.GetNamespace(),
+		}
+		if overlay.Target.Kind == "" || overlay.Target.Name == "" {
+			return overlay, fmt.Errorf("a strategic merge patch needs a target or its kind and name")
+		}
+	} else if *overlay.Target == (OverlayTarget{}) {
+		return overlay, fmt.Errorf("empty target")
+	}
+	overlay.mergePatch = patch
+	return overlay, validateTarget(overlay.Target)
+}
+
+func validateTarget(target *OverlayTarget) error {
+	if target.Group == rbacGroup || rbacKinds[target.Kind] {
+		return fmt.Errorf("the RBAC objects cannot be patched")
+	}
+	return nil
+}
+
+// Matches returns true if the object is selected by the overlay target, the
+// RBAC objects are never selected.
+func (o *Overlay) Matches(obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	if gvk.Group == rbacGroup {
+		return false
+	}
+	target := o.Target
+	return (target.Group == "" || target.Group == gvk.Group) &&
+		(target.Version == "" || target.Version == gvk.Version) &&
+		(target.Kind == "" || target.Kind == gvk.Kind) &&
+		(target.Name == "" || target.Name == obj.GetName()) &&
+		(target.Namespace == "" || target.Namespace == obj.GetNamespace())
+}
+
+// Apply patches the object. The kinds unknown to the client-go scheme, like
+// the CRDs, get a JSON merge patch instead of a strategic merge patch. The
+// patch cannot change the object kind, name or namespace.
+func (o *Overlay) Apply(obj *unstructured.Unstructured) error {
+	original, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	var patched []byte
+	if o.jsonPatch != nil {
+		patched, err = o.jsonPatch.Apply(original)
+	} else {
+		patched, err = o.applyMergePatch(obj.GroupVersionKind(), original)
+	}
+	if err != nil {
+		return fmt.Errorf("failed applying overlay %q to %s %s: %w", o.Name, obj.GetKind(), obj.GetName(), err)
+	}
+
+	result := &unstructured.Unstructured{}
+	if err := result.UnmarshalJSON(patched); err != nil {
+		return fmt.Errorf("failed applying overlay %q to %s %s: %w", o.Name, obj.GetKind(), obj.GetName(), err)
+	}
+	if result.GroupVersionKind() != obj.GroupVersionKind() || result.GetName() != obj.GetName() ||
+		result.GetNamespace() != obj.GetNamespace() {
+		return fmt.Errorf("overlay %q cannot change the kind, name or namespace of %s %s", o.Name, obj.GetKind(), obj.GetName())
+	}
+	obj.Object = result.Object
+	return nil
+}
+
+func (o *Overlay) applyMergePatch(gvk schema.GroupVersionKind, original []byte) ([]byte, error) {
+	typed, err := scheme.Scheme.New(gvk)
+	if err != nil {
+		return jsonpatch.MergePatch(original, o.mergePatch)
+	}
+	return strategicpatch.StrategicMergePatch(original, o.mergePatch, typed)
+}
+
+// ApplyOverlays patches the matching objects with every overlay in order and
+// returns the names of the overlays that matched any object.
+func ApplyOverlays(objs []*unstructured.Unstructured, overlays []Overlay) ([]string, error) {
+	matched := []string{}
+	for i := range overlays {
+		overlay := &overlays[i]
+		for _, obj := range objs {
+			if !overlay.Matches(obj) {
+				continue
+			}
+			if err := overlay.Apply(obj); err != nil {
+				return nil, err
+			}
+			if len(matched) == 0 || matched[len(matched)-1] != overlay.Name {
+				matched = append(matched, overlay.Name)
+			}
+		}
+	}
+	return matched, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseOverlays", func() {
+	type parseCase struct {
+		overlay        string
+		expectedTarget *OverlayTarget
+		expectedError  string
+	}
+	DescribeTable("parsing an overlay",
+		func(c parseCase) {
+			overlays, err := ParseOverlays(map[string]string{"overlay": c.overlay})
+			if c.expectedError != "" {
+				Expect(err).To(MatchError(ContainSubstring(c.expectedError)))
+				return
+			}
+			Expect(err).ToNot(HaveOccurred())
+			Expect(overlays).To(HaveLen(1))
+			Expect(overlays[0].Name).To(Equal("overlay"))
+			Expect(overlays[0].Target).To(Equal(c.expectedTarget))
+		},
+		Entry("strategic merge patch defaults its target to the object in it", parseCase{
+			overlay: `
+patch: |
+  apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: nmstate-webhook
+    namespace: nmstate
+  spec:
+    replicas: 3
+`,
+			expectedTarget: &OverlayTarget{Group: "apps", Version: "v1", Kind: "Deployment", Name: "nmstate-webhook", Namespace: "nmstate"},
+		}),
+		Entry("strategic merge patch keeps its explicit target", parseCase{
+			overlay: `
+target:
+  kind: DaemonSet
+patch: |
+  spec:
+    minReadySeconds: 10
+`,
+			expectedTarget: &OverlayTarget{Kind: "DaemonSet"},
+		}),
+		Entry("JSON patch with target", parseCase{
+			overlay: `
+target:
+  kind: Deployment
+  name: nmstate-metrics
+patch: |
+  - op: replace
+    path: /spec/replicas
+    value: 2
+`,
+			expectedTarget: &OverlayTarget{Kind: "Deployment", Name: "nmstate-metrics"},
+		}),
+		Entry("missing patch", parseCase{
+			overlay:       "target:\n  kind: Deployment\n",
+			expectedError: "missing patch",
+		}),
+		Entry("unknown field", parseCase{
+			overlay:       "selector: {}\npatch: '{}'\n",
+			expectedError: "unknown field",
+		}),
+		Entry("JSON patch without target", parseCase{
+			overlay:       "patch: |\n  - op: remove\n    path: /spec/replicas\n",
+			expectedError: "a JSON patch needs a target",
+		}),
+		Entry("strategic merge patch without target or name", parseCase{
+			overlay:       "patch: |\n  apiVersion: apps/v1\n  kind: Deployment\n  spec:\n    replicas: 3\n",
+			expectedError: "a strategic merge patch needs a target or its kind and name",
+		}),
+		Entry("empty target", parseCase{
+			overlay:       "target: {}\npatch: |\n  spec:\n    replicas: 3\n",
+			expectedError: "empty target",
+		}),
+		Entry("patch neither an object nor a list", parseCase{
+			overlay:       "target:\n  kind: Deployment\npatch: replicas\n",
+			expectedError: "neither a strategic merge patch object nor a JSON patch list",
+		}),
+		Entry("target RBAC kind", parseCase{
+			overlay:       "target:\n  kind: ClusterRole\npatch: |\n  - op: remove\n    path: /rules/0\n",
+			expectedError: "the RBAC objects cannot be patched",
+		}),
+		Entry("target RBAC group", parseCase{
+			overlay:       "target:\n  group: rbac.authorization.k8s.io\npatch: |\n  - op: remove\n    path: /subjects/0\n",
+			expectedError: "the RBAC objects cannot be patched",
+		}),
+		Entry("strategic merge patch of a RBAC object", parseCase{
+			overlay: `
+patch: |
+  apiVersion: rbac.authorization.k8s.io/v1
+  kind: RoleBinding
+  metadata:
+    name: nmstate-handler
+`,
+			expectedError: "the RBAC objects cannot be patched",
+		}),
+	)
+
+	It("should return the overlays in name order", func() {
+		patch := "target:\n  kind: Deployment\npatch: |\n  spec:\n    replicas: 3\n"
+		overlays, err := ParseOverlays(map[string]string{"b": patch, "c": patch, "a": patch})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(overlays).To(HaveLen(3))
+		Expect([]string{overlays[0].Name, overlays[1].Name, overlays[2].Name}).To(Equal([]string{"a", "b", "c"}))
+	})
+})
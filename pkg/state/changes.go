@@ -0,0 +1,187 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	yaml "sigs.k8s.io/yaml"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+var defaultRouteDestinations = map[string]string{
+	"0.0.0.0/0": "IPv4",
+	"::/0":      "IPv6",
+}
+
+// SummarizeChanges returns a human readable summary of the changes between
+// both states, for example "bond0 lost member eth2" or "IPv4 default route
+// removed". The changes not summarized are reported by top level section.
+func SummarizeChanges(previousState, currentState shared.State) ([]string, error) {
+	var previous, current rootState
+	if err := yaml.Unmarshal(previousState.Raw, &previous); err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(currentState.Raw, &current); err != nil {
+		return nil, err
+	}
+
+	summary := summarizeInterfacesChanges(previous.Interfaces, current.Interfaces)
+	summary = append(summary, summarizeDefaultRoutesChanges(previous.Routes, current.Routes)...)
+	if !sameDNSServers(previous.DNSResolver, current.DNSResolver) {
+		summary = append(summary, "DNS servers changed")
+	}
+	if len(summary) > 0 {
+		return summary, nil
+	}
+
+	changedSections, err := ChangedSections(previousState, currentState)
+	if err != nil {
+		return nil, err
+	}
+	for _, section := range changedSections {
+		summary = append(summary, fmt.Sprintf("%s changed", section))
+	}
+	return summary, nil
+}
+
+// interfaceKey identifies an interface by name and type, nmstate reports
+// interfaces of different types sharing a name, for example an ovs-bridge and
+// its ovs-interface.
+type interfaceKey struct {
+	name          string
+	interfaceType string
+}
+
+func interfacesByKey(interfaces []interfaceState) map[interfaceKey]interfaceState {
+	byKey := map[interfaceKey]interfaceState{}
+	for _, iface := range interfaces {
+		byKey[interfaceKey{name: iface.Name, interfaceType: iface.Type}] = iface
+	}
+	return byKey
+}
+
+func summarizeInterfacesChanges(previousInterfaces, currentInterfaces []interfaceState) []string {
+	previousByKey := interfacesByKey(previousInterfaces)
+	currentByKey := interfacesByKey(currentInterfaces)
+
+	summary := []string{}
+	for _, key := range sortedInterfaceKeys(currentByKey) {
+		current := currentByKey[key]
+		previous, found := previousByKey[key]
+		if !found {
+			summary = append(summary, fmt.Sprintf("%s %s added", key.interfaceType, key.name))
+			continue
+		}
+		previousState, _ := previous.Data["state"].(string)
+		currentState, _ := current.Data["state"].(string)
+		if previousState != currentState {
+			summary = append(summary, fmt.Sprintf("%s changed state from %s to %s", key.name, previousState, currentState))
+		}
+		summary = append(summary, summarizeBondPortsChanges(key.name, previous.Data, current.Data)...)
+	}
+	for _, key := range sortedInterfaceKeys(previousByKey) {
+		if _, found := currentByKey[key]; !found {
+			summary = append(summary, fmt.Sprintf("%s %s removed", key.interfaceType, key.name))
+		}
+	}
+	return summary
+}
+
+func summarizeBondPortsChanges(name string, previousData, currentData map[string]any) []string {
+	previousPorts := map[string]bool{}
+	for _, port := range bondPorts(previousData) {
+		previousPorts[port] = true
+	}
+	currentPorts := map[string]bool{}
+	for _, port := range bondPorts(currentData) {
+		currentPorts[port] = true
+	}
+	summary := []string{}
+	for _, port := range bondPorts(previousData) {
+		if !currentPorts[port] {
+			summary = append(summary, fmt.Sprintf("%s lost member %s", name, port))
+		}
+	}
+	for _, port := range bondPorts(currentData) {
+		if !previousPorts[port] {
+			summary = append(summary, fmt.Sprintf("%s gained member %s", name, port))
+		}
+	}
+	return summary
+}
+
+func summarizeDefaultRoutesChanges(previousRoutes, currentRoutes *routes) []string {
+	previousFamilies := defaultRouteFamilies(previousRoutes)
+	currentFamilies := defaultRouteFamilies(currentRoutes)
+	summary := []string{}
+	for _, family := range []string{"IPv4", "IPv6"} {
+		switch {
+		case previousFamilies[family] && !currentFamilies[family]:
+			summary = append(summary, fmt.Sprintf("%s default route removed", family))
+		case !previousFamilies[family] && currentFamilies[family]:
+			summary = append(summary, fmt.Sprintf("%s default route added", family))
+		}
+	}
+	return summary
+}
+
+func defaultRouteFamilies(currentRoutes *routes) map[string]bool {
+	families := map[string]bool{}
+	if currentRoutes == nil {
+		return families
+	}
+	for _, route := range currentRoutes.Running {
+		if family, isDefault := defaultRouteDestinations[route.Destination]; isDefault {
+			families[family] = true
+		}
+	}
+	return families
+}
+
+func sameDNSServers(previousDNSResolver, currentDNSResolver *dnsResolver) bool {
+	return strings.Join(runningDNSServers(previousDNSResolver), ",") == strings.Join(runningDNSServers(currentDNSResolver), ",")
+}
+
+func runningDNSServers(resolver *dnsResolver) []string {
+	if resolver == nil || resolver.Running == nil {
+		return nil
+	}
+	servers := []string{}
+	for _, server := range resolver.Running.Server {
+		servers = append(servers, fmt.Sprint(server))
+	}
+	return servers
+}
+
+func sortedInterfaceKeys(interfaces map[interfaceKey]interfaceState) []interfaceKey {
+	keys := make([]interfaceKey, 0, len(interfaces))
+	for key := range interfaces {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].interfaceType < keys[j].interfaceType
+	})
+	return keys
+}
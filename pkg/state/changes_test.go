@@ -0,0 +1,147 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nmstate "github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+var _ = Describe("SummarizeChanges", func() {
+	previousState := nmstate.NewState(`
+dns-resolver:
+  running:
+    search: []
+    server:
+    - 10.0.0.1
+interfaces:
+- name: bond0
+  type: bond
+  state: up
+  link-aggregation:
+    mode: active-backup
+    port:
+    - eth1
+    - eth2
+- name: eth1
+  type: ethernet
+  state: up
+- name: eth2
+  type: ethernet
+  state: up
+routes:
+  config: []
+  running:
+  - destination: 0.0.0.0/0
+    next-hop-interface: bond0
+    next-hop-address: 10.0.0.254
+`)
+	It("should summarize interface, bond port, default route and DNS changes", func() {
+		summary, err := SummarizeChanges(previousState, nmstate.NewState(`
+dns-resolver:
+  running:
+    search: []
+    server:
+    - 10.0.0.2
+interfaces:
+- name: bond0
+  type: bond
+  state: up
+  link-aggregation:
+    mode: active-backup
+    port:
+    - eth1
+- name: eth1
+  type: ethernet
+  state: up
+- name: eth2
+  type: ethernet
+  state: down
+- name: vlan10
+  type: vlan
+  state: up
+routes:
+  config: []
+  running: []
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(summary).To(Equal([]string{
+			"bond0 lost member eth2",
+			"eth2 changed state from up to down",
+			"vlan vlan10 added",
+			"IPv4 default route removed",
+			"DNS servers changed",
+		}))
+	})
+	It("should report the changed sections when there is nothing else to summarize", func() {
+		summary, err := SummarizeChanges(previousState, nmstate.NewState(`
+dns-resolver:
+  running:
+    search: []
+    server:
+    - 10.0.0.1
+interfaces:
+- name: bond0
+  type: bond
+  state: up
+  link-aggregation:
+    mode: active-backup
+    port:
+    - eth1
+    - eth2
+- name: eth1
+  type: ethernet
+  state: up
+  mtu: 9000
+- name: eth2
+  type: ethernet
+  state: up
+routes:
+  config: []
+  running:
+  - destination: 0.0.0.0/0
+    next-hop-interface: bond0
+    next-hop-address: 10.0.0.254
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(summary).To(Equal([]string{"interfaces changed"}))
+	})
+	It("should tell apart the interfaces sharing a name by their type", func() {
+		summary, err := SummarizeChanges(nmstate.NewState(`
+interfaces:
+- name: br0
+  type: ovs-bridge
+  state: up
+- name: br0
+  type: ovs-interface
+  state: up
+`), nmstate.NewState(`
+interfaces:
+- name: br0
+  type: ovs-bridge
+  state: up
+- name: br0
+  type: ovs-interface
+  state: down
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(summary).To(Equal([]string{"br0 changed state from up to down"}))
+	})
+})
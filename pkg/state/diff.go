@@ -0,0 +1,54 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+// Diff returns the unified diff between both states, the states have to be
+// already filtered and redacted.
+func Diff(previousState, currentState shared.State) (string, error) {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(previousState.String()),
+		B:        splitLines(currentState.String()),
+		FromFile: "previous",
+		ToFile:   "current",
+		Context:  3,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed calculating state diff")
+	}
+	return diff, nil
+}
+
+func splitLines(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	if !strings.HasSuffix(raw, "\n") {
+		raw += "\n"
+	}
+	lines := strings.SplitAfter(raw, "\n")
+	return lines[:len(lines)-1]
+}
@@ -0,0 +1,48 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nmstate "github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+var _ = Describe("Diff", func() {
+	It("should return the unified diff between the states", func() {
+		diff, err := Diff(
+			nmstate.NewState("interfaces:\n- name: eth1\n  state: up\n"),
+			nmstate.NewState("interfaces:\n- name: eth1\n  state: down\n"),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff).To(Equal(`--- previous
++++ current
+@@ -1,3 +1,3 @@
+ interfaces:
+ - name: eth1
+-  state: up
++  state: down
+`))
+	})
+	It("should return an empty diff for equal states", func() {
+		diff, err := Diff(nmstate.NewState("interfaces: []\n"), nmstate.NewState("interfaces: []\n"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff).To(BeEmpty())
+	})
+})
@@ -23,21 +23,41 @@ import (
 	"github.com/nmstate/kubernetes-nmstate/api/shared"
 	"github.com/nmstate/kubernetes-nmstate/pkg/environment"
 
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	yaml "sigs.k8s.io/yaml"
 )
 
+var log = logf.Log.WithName("state")
+
 const (
-	InterfaceFilter = "interface_filter"
+	// InterfaceFilter is the environment variable with the JSON encoded
+	// interface filter configured at the NMState CR
+	InterfaceFilter = "INTERFACE_FILTER"
 )
 
 func init() {
-	if !environment.IsHandler() {
+	if !environment.IsHandler() && !environment.IsMetricsManager() {
 		return
 	}
+	if rawFilter := environment.GetEnvVar(InterfaceFilter, ""); rawFilter != "" {
+		filter, err := ParseInterfaceFilter(rawFilter)
+		if err != nil {
+			log.Error(err, "ignoring interface filter")
+		} else {
+			SetInterfaceFilter(filter)
+		}
+	}
+	if redactPathsEnv := environment.GetEnvVar(RedactPathsEnvVar, ""); redactPathsEnv != "" {
+		SetRedactPaths(strings.Split(redactPathsEnv, ","))
+	}
 }
 
 func FilterOut(currentState shared.State) (shared.State, error) {
-	return filterOut(currentState)
+	filteredState, err := filterOut(currentState)
+	if err != nil {
+		return filteredState, err
+	}
+	return Redact(filteredState)
 }
 
 // CountInterfacesByType parses the state and returns a map of interface type to count.
@@ -46,6 +66,7 @@ func CountInterfacesByType(currentState shared.State) (map[string]int, error) {
 	if err := yaml.Unmarshal(currentState.Raw, &state); err != nil {
 		return nil, err
 	}
+	applyInterfaceFilter(&state)
 
 	counts := make(map[string]int)
 	for _, iface := range state.Interfaces {
@@ -71,6 +92,7 @@ func CountRoutes(currentState shared.State) (map[RouteKey]int, error) {
 	if err := yaml.Unmarshal(currentState.Raw, &state); err != nil {
 		return nil, err
 	}
+	applyInterfaceFilter(&state)
 
 	counts := make(map[RouteKey]int)
 	if state.Routes == nil {
@@ -200,12 +222,15 @@ func filterOutAddressLifetimes(iface map[string]any, ipVersion string) {
 	}
 }
 
-func filterOutInterfaces(ifacesState []interfaceState) []interfaceState {
+func filterOutInterfaces(ifacesState []interfaceState, filter *shared.InterfaceFilter) []interfaceState {
 	filteredInterfaces := []interfaceState{}
 	for _, iface := range ifacesState {
 		if isVeth(iface.Data) && isUnmanaged(iface.Data) {
 			continue
 		}
+		if isFilteredOut(filter, iface) {
+			continue
+		}
 		filterOutDynamicAttributes(iface.Data)
 		filteredInterfaces = append(filteredInterfaces, iface)
 	}
@@ -226,11 +251,7 @@ func filterOut(currentState shared.State) (shared.State, error) {
 		return currentState, err
 	}
 
-	state.Interfaces = filterOutInterfaces(state.Interfaces)
-	if state.Routes != nil {
-		state.Routes.Running = filterOutRoutes(state.Routes.Running, state.Interfaces)
-		state.Routes.Config = filterOutRoutes(state.Routes.Config, state.Interfaces)
-	}
+	filterOutRootState(&state)
 
 	filteredState, err := yaml.Marshal(state)
 	if err != nil {
@@ -239,3 +260,24 @@ func filterOut(currentState shared.State) (shared.State, error) {
 
 	return shared.NewState(string(filteredState)), nil
 }
+
+func filterOutRootState(state *rootState) {
+	filter := currentInterfaceFilter()
+	state.Interfaces = filterOutInterfaces(state.Interfaces, filter)
+	if state.Routes != nil {
+		state.Routes.Running = filterOutRoutes(state.Routes.Running, state.Interfaces)
+		state.Routes.Config = filterOutRoutes(state.Routes.Config, state.Interfaces)
+		if filter != nil && filter.DropDynamicRoutes {
+			state.Routes.Running = filterOutDynamicRoutes(state.Routes.Running, state.Routes.Config)
+		}
+	}
+}
+
+// applyInterfaceFilter makes counters consistent with the reported state when
+// an interface filter is configured.
+func applyInterfaceFilter(state *rootState) {
+	if currentInterfaceFilter() == nil {
+		return
+	}
+	filterOutRootState(state)
+}
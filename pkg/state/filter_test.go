@@ -575,3 +575,123 @@ routes:
 		})
 	})
 })
+
+var _ = Describe("Interface filter", func() {
+	var state nmstate.State
+
+	BeforeEach(func() {
+		state = nmstate.NewState(`interfaces:
+- name: eth1
+  state: up
+  type: ethernet
+- name: eth2
+  state: down
+  type: ethernet
+- name: veth1
+  state: up
+  type: veth
+- name: tap0
+  state: up
+  type: tun
+- name: br1
+  state: up
+  type: linux-bridge
+routes:
+  config:
+  - destination: 10.0.0.0/24
+    next-hop-address: 192.168.66.1
+    next-hop-interface: eth1
+  running:
+  - destination: 10.0.0.0/24
+    next-hop-address: 192.168.66.1
+    next-hop-interface: eth1
+  - destination: 0.0.0.0/0
+    next-hop-address: 192.168.66.2
+    next-hop-interface: eth1
+  - destination: 192.168.100.0/24
+    next-hop-address: ""
+    next-hop-interface: veth1
+`)
+	})
+	AfterEach(func() {
+		SetInterfaceFilter(nil)
+	})
+
+	Context("when excluding names, types and states", func() {
+		BeforeEach(func() {
+			SetInterfaceFilter(&nmstate.InterfaceFilter{
+				ExcludeNames:  []string{"veth*"},
+				ExcludeTypes:  []string{"tun"},
+				ExcludeStates: []string{"down"},
+			})
+		})
+		It("should remove the matching interfaces and their routes", func() {
+			filteredState, err := FilterOut(state)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filteredState).To(MatchYAML(`interfaces:
+- name: eth1
+  state: up
+  type: ethernet
+- name: br1
+  state: up
+  type: linux-bridge
+routes:
+  config:
+  - destination: 10.0.0.0/24
+    next-hop-address: 192.168.66.1
+    next-hop-interface: eth1
+  running:
+  - destination: 10.0.0.0/24
+    next-hop-address: 192.168.66.1
+    next-hop-interface: eth1
+  - destination: 0.0.0.0/0
+    next-hop-address: 192.168.66.2
+    next-hop-interface: eth1
+`))
+		})
+		It("should not count the filtered out interfaces", func() {
+			counts, err := CountInterfacesByType(state)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(counts).To(Equal(map[string]int{"ethernet": 1, "linux-bridge": 1}))
+		})
+	})
+
+	Context("when including names and types", func() {
+		BeforeEach(func() {
+			SetInterfaceFilter(&nmstate.InterfaceFilter{
+				IncludeNames: []string{"eth*", "br*"},
+				IncludeTypes: []string{"ethernet"},
+			})
+		})
+		It("should keep only the interfaces matching both", func() {
+			counts, err := CountInterfacesByType(state)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(counts).To(Equal(map[string]int{"ethernet": 2}))
+		})
+	})
+
+	Context("when dropping dynamic routes", func() {
+		BeforeEach(func() {
+			SetInterfaceFilter(&nmstate.InterfaceFilter{
+				DropDynamicRoutes: true,
+			})
+		})
+		It("should keep only the configured running routes", func() {
+			counts, err := CountRoutes(state)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(counts).To(Equal(map[RouteKey]int{{IPStack: "ipv4", Type: "static"}: 1}))
+		})
+	})
+})
+
+var _ = Describe("ParseInterfaceFilter", func() {
+	It("should decode a valid filter", func() {
+		filter, err := ParseInterfaceFilter(`{"excludeNames":["veth*"],"dropDynamicRoutes":true}`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filter).To(Equal(&nmstate.InterfaceFilter{ExcludeNames: []string{"veth*"}, DropDynamicRoutes: true}))
+	})
+	It("should fail with an invalid glob", func() {
+		_, err := ParseInterfaceFilter(`{"includeNames":["eth["]}`)
+		Expect(err).To(HaveOccurred())
+	})
+})
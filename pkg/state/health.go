@@ -0,0 +1,198 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	yaml "sigs.k8s.io/yaml"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+// HealthCheck is the result of checking a NodeNetworkState health condition,
+// the condition is true if the node network state has the issue.
+type HealthCheck struct {
+	Type    shared.ConditionType
+	Failing bool
+	Message string
+}
+
+// CheckHealth runs the NodeNetworkState health checks over the current state,
+// the interfaces at upInterfaces are expected to be up since they are
+// configured so by the applied policies. The checks are returned in the
+// shared.NodeNetworkStateHealthConditions order.
+func CheckHealth(currentState shared.State, upInterfaces []string) ([]HealthCheck, error) {
+	var state rootState
+	if err := yaml.Unmarshal(currentState.Raw, &state); err != nil {
+		return nil, err
+	}
+	linksHealth, err := InterfacesLinkHealth(currentState)
+	if err != nil {
+		return nil, err
+	}
+	return []HealthCheck{
+		checkDefaultRoute(state.Routes),
+		checkDNS(state.DNSResolver),
+		checkBonds(linksHealth),
+		checkInterfacesUp(state.Interfaces, upInterfaces),
+		checkDuplicateAddresses(state.Interfaces),
+	}, nil
+}
+
+// UpInterfaces returns the names of the interfaces the desired state
+// configures up, nmstate defaults the state to up if it is not specified.
+func UpInterfaces(desiredState shared.State) ([]string, error) {
+	var state rootState
+	if err := yaml.Unmarshal(desiredState.Raw, &state); err != nil {
+		return nil, err
+	}
+	upInterfaces := []string{}
+	for _, iface := range state.Interfaces {
+		ifaceState, _ := iface.Data["state"].(string)
+		if ifaceState == "" || ifaceState == "up" {
+			upInterfaces = append(upInterfaces, iface.Name)
+		}
+	}
+	return upInterfaces, nil
+}
+
+func checkDefaultRoute(currentRoutes *routes) HealthCheck {
+	check := HealthCheck{Type: shared.NodeNetworkStateConditionDefaultRouteMissing}
+	families := defaultRouteFamilies(currentRoutes)
+	if len(families) == 0 {
+		check.Failing = true
+		check.Message = "no IPv4 nor IPv6 default route"
+		return check
+	}
+	found := []string{}
+	for _, family := range []string{"IPv4", "IPv6"} {
+		if families[family] {
+			found = append(found, family)
+		}
+	}
+	check.Message = fmt.Sprintf("%s default route found", strings.Join(found, " and "))
+	return check
+}
+
+func checkDNS(resolver *dnsResolver) HealthCheck {
+	check := HealthCheck{Type: shared.NodeNetworkStateConditionDNSUnconfigured}
+	servers := runningDNSServers(resolver)
+	if len(servers) == 0 {
+		check.Failing = true
+		check.Message = "no DNS server configured"
+		return check
+	}
+	check.Message = fmt.Sprintf("DNS servers: %s", strings.Join(servers, ", "))
+	return check
+}
+
+func checkBonds(linksHealth []LinkHealth) HealthCheck {
+	check := HealthCheck{Type: shared.NodeNetworkStateConditionBondDegraded}
+	degraded := []string{}
+	for _, linkHealth := range linksHealth {
+		if linkHealth.IsBond && linkHealth.Up && linkHealth.BondInactivePorts > 0 {
+			degraded = append(degraded, fmt.Sprintf("%s has %d/%d ports up",
+				linkHealth.Name, linkHealth.BondActivePorts, linkHealth.BondActivePorts+linkHealth.BondInactivePorts))
+		}
+	}
+	if len(degraded) == 0 {
+		check.Message = "all bond ports are up"
+		return check
+	}
+	check.Failing = true
+	check.Message = strings.Join(degraded, "; ")
+	return check
+}
+
+func checkInterfacesUp(interfaces []interfaceState, upInterfaces []string) HealthCheck {
+	check := HealthCheck{Type: shared.NodeNetworkStateConditionInterfaceDown}
+	isUp := map[string]bool{}
+	for _, iface := range interfaces {
+		isUp[iface.Name] = iface.Data["state"] == "up"
+	}
+	down := []string{}
+	for _, name := range upInterfaces {
+		if up, found := isUp[name]; found && !up {
+			down = append(down, name)
+		}
+	}
+	if len(down) == 0 {
+		check.Message = "all interfaces configured up are up"
+		return check
+	}
+	sort.Strings(down)
+	check.Failing = true
+	check.Message = fmt.Sprintf("interfaces configured up are down: %s", strings.Join(down, ", "))
+	return check
+}
+
+func checkDuplicateAddresses(interfaces []interfaceState) HealthCheck {
+	check := HealthCheck{Type: shared.NodeNetworkStateConditionDuplicateAddress}
+	interfacesByAddress := map[string][]string{}
+	for _, iface := range interfaces {
+		for _, address := range interfaceAddresses(iface.Data) {
+			interfacesByAddress[address] = append(interfacesByAddress[address], iface.Name)
+		}
+	}
+	duplicates := []string{}
+	for address, names := range interfacesByAddress {
+		if len(names) > 1 {
+			sort.Strings(names)
+			duplicates = append(duplicates, fmt.Sprintf("%s is at %s", address, strings.Join(names, ", ")))
+		}
+	}
+	if len(duplicates) == 0 {
+		check.Message = "no duplicate addresses"
+		return check
+	}
+	sort.Strings(duplicates)
+	check.Failing = true
+	check.Message = strings.Join(duplicates, "; ")
+	return check
+}
+
+// interfaceAddresses returns the IPv4 and IPv6 addresses of the interface
+// without the link local ones, they can be repeated at the interfaces sharing
+// a MAC address like VLANs.
+func interfaceAddresses(ifaceData map[string]any) []string {
+	addresses := []string{}
+	for _, family := range []string{"ipv4", "ipv6"} {
+		ipConfig, ok := ifaceData[family].(map[string]any)
+		if !ok {
+			continue
+		}
+		rawAddresses, _ := ipConfig["address"].([]any)
+		for _, rawAddress := range rawAddresses {
+			address, ok := rawAddress.(map[string]any)
+			if !ok {
+				continue
+			}
+			rawIP, _ := address["ip"].(string)
+			ip := net.ParseIP(rawIP)
+			if ip == nil || ip.IsLinkLocalUnicast() || ip.IsLoopback() {
+				continue
+			}
+			addresses = append(addresses, ip.String())
+		}
+	}
+	return addresses
+}
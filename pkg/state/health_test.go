@@ -0,0 +1,147 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nmstate "github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+var _ = Describe("CheckHealth", func() {
+	failing := func(healthChecks []HealthCheck) map[nmstate.ConditionType]string {
+		failingChecks := map[nmstate.ConditionType]string{}
+		for _, healthCheck := range healthChecks {
+			if healthCheck.Failing {
+				failingChecks[healthCheck.Type] = healthCheck.Message
+			}
+		}
+		return failingChecks
+	}
+	It("should pass all the checks with a healthy state", func() {
+		healthChecks, err := CheckHealth(nmstate.NewState(`
+dns-resolver:
+  running:
+    search: []
+    server:
+    - 10.0.0.1
+interfaces:
+- name: bond0
+  type: bond
+  state: up
+  ipv4:
+    enabled: true
+    address:
+    - ip: 10.0.0.2
+      prefix-length: 24
+  link-aggregation:
+    mode: active-backup
+    port:
+    - eth1
+- name: eth1
+  type: ethernet
+  state: up
+routes:
+  config: []
+  running:
+  - destination: 0.0.0.0/0
+    next-hop-interface: bond0
+`), []string{"bond0"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(healthChecks).To(HaveLen(len(nmstate.NodeNetworkStateHealthConditions)))
+		for i, healthCheck := range healthChecks {
+			Expect(healthCheck.Type).To(Equal(nmstate.NodeNetworkStateHealthConditions[i]))
+		}
+		Expect(failing(healthChecks)).To(BeEmpty())
+	})
+	It("should report the issues of an unhealthy state", func() {
+		healthChecks, err := CheckHealth(nmstate.NewState(`
+interfaces:
+- name: bond0
+  type: bond
+  state: up
+  ipv4:
+    enabled: true
+    address:
+    - ip: 10.0.0.2
+      prefix-length: 24
+  ipv6:
+    enabled: true
+    address:
+    - ip: fe80::1
+      prefix-length: 64
+  link-aggregation:
+    mode: active-backup
+    port:
+    - eth1
+    - eth2
+- name: eth1
+  type: ethernet
+  state: up
+- name: eth2
+  type: ethernet
+  state: down
+- name: bond0.10
+  type: vlan
+  state: up
+  ipv4:
+    enabled: true
+    address:
+    - ip: 10.0.0.2
+      prefix-length: 24
+  ipv6:
+    enabled: true
+    address:
+    - ip: fe80::1
+      prefix-length: 64
+routes:
+  config: []
+  running: []
+`), []string{"bond0", "eth2", "eth3"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(failing(healthChecks)).To(Equal(map[nmstate.ConditionType]string{
+			nmstate.NodeNetworkStateConditionDefaultRouteMissing: "no IPv4 nor IPv6 default route",
+			nmstate.NodeNetworkStateConditionDNSUnconfigured:     "no DNS server configured",
+			nmstate.NodeNetworkStateConditionBondDegraded:        "bond0 has 1/2 ports up",
+			nmstate.NodeNetworkStateConditionInterfaceDown:       "interfaces configured up are down: eth2",
+			nmstate.NodeNetworkStateConditionDuplicateAddress:    "10.0.0.2 is at bond0, bond0.10",
+		}))
+	})
+})
+
+var _ = Describe("UpInterfaces", func() {
+	It("should return the interfaces configured up or without state", func() {
+		upInterfaces, err := UpInterfaces(nmstate.NewState(`
+interfaces:
+- name: eth1
+  type: ethernet
+  state: up
+- name: eth2
+  type: ethernet
+  state: down
+- name: eth3
+  type: ethernet
+- name: eth4
+  type: ethernet
+  state: absent
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upInterfaces).To(Equal([]string{"eth1", "eth3"}))
+	})
+})
@@ -0,0 +1,120 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"encoding/json"
+	"path"
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+var (
+	interfaceFilterLock sync.RWMutex
+	interfaceFilter     *shared.InterfaceFilter
+)
+
+// SetInterfaceFilter configures the filter applied by FilterOut and by the
+// interfaces and routes counters, nil disables it.
+func SetInterfaceFilter(filter *shared.InterfaceFilter) {
+	interfaceFilterLock.Lock()
+	defer interfaceFilterLock.Unlock()
+	interfaceFilter = filter
+}
+
+func currentInterfaceFilter() *shared.InterfaceFilter {
+	interfaceFilterLock.RLock()
+	defer interfaceFilterLock.RUnlock()
+	return interfaceFilter
+}
+
+// ParseInterfaceFilter decodes and validates the JSON interface filter
+// passed by the operator to the handler and metrics pods.
+func ParseInterfaceFilter(rawFilter string) (*shared.InterfaceFilter, error) {
+	filter := &shared.InterfaceFilter{}
+	if err := json.Unmarshal([]byte(rawFilter), filter); err != nil {
+		return nil, errors.Wrap(err, "failed decoding interface filter")
+	}
+	if err := ValidateInterfaceFilter(filter); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// ValidateInterfaceFilter checks that the name patterns are valid globs.
+func ValidateInterfaceFilter(filter *shared.InterfaceFilter) error {
+	if filter == nil {
+		return nil
+	}
+	for _, pattern := range slices.Concat(filter.IncludeNames, filter.ExcludeNames) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrapf(err, "invalid interface name pattern '%s'", pattern)
+		}
+	}
+	return nil
+}
+
+func isFilteredOut(filter *shared.InterfaceFilter, iface interfaceState) bool {
+	if filter == nil {
+		return false
+	}
+	if len(filter.IncludeNames) > 0 && !matchesAny(filter.IncludeNames, iface.Name) {
+		return true
+	}
+	if matchesAny(filter.ExcludeNames, iface.Name) {
+		return true
+	}
+	if len(filter.IncludeTypes) > 0 && !slices.Contains(filter.IncludeTypes, iface.Type) {
+		return true
+	}
+	if slices.Contains(filter.ExcludeTypes, iface.Type) {
+		return true
+	}
+	ifaceState, _ := iface.Data["state"].(string)
+	return slices.Contains(filter.ExcludeStates, ifaceState)
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		// Patterns are validated by the operator, a bad one just does not match
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// filterOutDynamicRoutes keeps only the running routes that are also part of
+// the routes configuration.
+func filterOutDynamicRoutes(running, config []routeState) []routeState {
+	configured := map[routeFields]struct{}{}
+	for _, route := range config {
+		configured[route.routeFields] = struct{}{}
+	}
+	staticRoutes := []routeState{}
+	for _, route := range running {
+		if _, isStatic := configured[route.routeFields]; isStatic {
+			staticRoutes = append(staticRoutes, route)
+		}
+	}
+	return staticRoutes
+}
@@ -0,0 +1,209 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+
+	"github.com/pkg/errors"
+	yaml "sigs.k8s.io/yaml"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+const (
+	// InterfaceMetrics is the environment variable with the JSON encoded per
+	// interface metrics configuration, the metrics are disabled if not set
+	InterfaceMetrics = "INTERFACE_METRICS"
+)
+
+// LinkHealth is the link health of an interface exposed by the per interface
+// metrics
+type LinkHealth struct {
+	Name string
+	Type string
+	Up   bool
+	// MTU is zero if it is not reported
+	MTU int64
+	// SpeedMbps is zero if the interface is not ethernet or the speed is unknown
+	SpeedMbps int64
+	// BondActivePorts and BondInactivePorts count the bond ports that are up
+	// and the ones that are not
+	IsBond            bool
+	BondActivePorts   int
+	BondInactivePorts int
+	// LLDPNeighbors is only meaningful if LLDP is enabled at the interface
+	LLDPEnabled   bool
+	LLDPNeighbors int
+}
+
+// ParseInterfaceMetrics decodes and validates the JSON per interface metrics
+// configuration passed by the operator to the metrics pod.
+func ParseInterfaceMetrics(rawInterfaceMetrics string) (*shared.InterfaceMetrics, error) {
+	interfaceMetrics := &shared.InterfaceMetrics{}
+	if err := json.Unmarshal([]byte(rawInterfaceMetrics), interfaceMetrics); err != nil {
+		return nil, errors.Wrap(err, "failed decoding interface metrics")
+	}
+	if err := ValidateInterfaceMetrics(interfaceMetrics); err != nil {
+		return nil, err
+	}
+	return interfaceMetrics, nil
+}
+
+// ValidateInterfaceMetrics checks that the allowlist patterns are valid globs.
+func ValidateInterfaceMetrics(interfaceMetrics *shared.InterfaceMetrics) error {
+	if interfaceMetrics == nil {
+		return nil
+	}
+	for _, pattern := range interfaceMetrics.IncludeNames {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrapf(err, "invalid interface name pattern '%s'", pattern)
+		}
+	}
+	return nil
+}
+
+// InterfacesLinkHealth parses the state and returns the link health of the
+// interfaces not filtered out, sorted by name and type.
+func InterfacesLinkHealth(currentState shared.State) ([]LinkHealth, error) {
+	var state rootState
+	if err := yaml.Unmarshal(currentState.Raw, &state); err != nil {
+		return nil, err
+	}
+	applyInterfaceFilter(&state)
+
+	isUp := map[string]bool{}
+	for _, iface := range state.Interfaces {
+		if iface.Data["state"] == "up" {
+			isUp[iface.Name] = true
+		}
+	}
+
+	linksHealth := []LinkHealth{}
+	for _, iface := range state.Interfaces {
+		linkHealth := LinkHealth{
+			Name: iface.Name,
+			Type: iface.Type,
+			Up:   iface.Data["state"] == "up",
+			MTU:  asInt64(iface.Data["mtu"]),
+		}
+		if ethernet, ok := iface.Data["ethernet"].(map[string]any); ok {
+			linkHealth.SpeedMbps = asInt64(ethernet["speed"])
+		}
+		if iface.Type == "bond" {
+			linkHealth.IsBond = true
+			for _, port := range bondPorts(iface.Data) {
+				if isUp[port] {
+					linkHealth.BondActivePorts++
+				} else {
+					linkHealth.BondInactivePorts++
+				}
+			}
+		}
+		if lldp, ok := iface.Data["lldp"].(map[string]any); ok {
+			linkHealth.LLDPEnabled, _ = lldp["enabled"].(bool)
+			neighbors, _ := lldp["neighbors"].([]any)
+			linkHealth.LLDPNeighbors = len(neighbors)
+		}
+		linksHealth = append(linksHealth, linkHealth)
+	}
+	sort.Slice(linksHealth, func(i, j int) bool {
+		if linksHealth[i].Name != linksHealth[j].Name {
+			return linksHealth[i].Name < linksHealth[j].Name
+		}
+		return linksHealth[i].Type < linksHealth[j].Type
+	})
+	return linksHealth, nil
+}
+
+// SelectLinksHealth keeps the interfaces matching the allowlist up to the
+// cardinality limit, it returns how many were dropped because of the limit.
+func SelectLinksHealth(linksHealth []LinkHealth, interfaceMetrics *shared.InterfaceMetrics) ([]LinkHealth, int) {
+	maxInterfaces := int(interfaceMetrics.MaxInterfacesPerNode)
+	if maxInterfaces <= 0 {
+		maxInterfaces = shared.DefaultMaxInterfacesPerNode
+	}
+	selected := []LinkHealth{}
+	dropped := 0
+	for _, linkHealth := range linksHealth {
+		if len(interfaceMetrics.IncludeNames) > 0 && !matchesAny(interfaceMetrics.IncludeNames, linkHealth.Name) {
+			continue
+		}
+		if len(selected) >= maxInterfaces {
+			dropped++
+			continue
+		}
+		selected = append(selected, linkHealth)
+	}
+	return selected, dropped
+}
+
+// JoinInterfaces replaces the interfaces of the state with the full state of
+// every interface, it reverts SplitInterfaces.
+func JoinInterfaces(currentState shared.State, interfaces []shared.State) (shared.State, error) {
+	var state rootState
+	if err := yaml.Unmarshal(currentState.Raw, &state); err != nil {
+		return currentState, err
+	}
+	state.Interfaces = []interfaceState{}
+	for _, ifaceState := range interfaces {
+		var iface interfaceState
+		if err := yaml.Unmarshal(ifaceState.Raw, &iface); err != nil {
+			return currentState, err
+		}
+		state.Interfaces = append(state.Interfaces, iface)
+	}
+	joinedState, err := yaml.Marshal(state)
+	if err != nil {
+		return currentState, err
+	}
+	return shared.NewState(string(joinedState)), nil
+}
+
+func bondPorts(ifaceData map[string]any) []string {
+	linkAggregation, ok := ifaceData["link-aggregation"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	rawPorts, ok := linkAggregation["port"].([]any)
+	if !ok {
+		// Older nmstate versions name them slaves
+		rawPorts, _ = linkAggregation["slaves"].([]any)
+	}
+	ports := []string{}
+	for _, rawPort := range rawPorts {
+		if port, ok := rawPort.(string); ok {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+func asInt64(value any) int64 {
+	switch number := value.(type) {
+	case float64:
+		return int64(number)
+	case int64:
+		return number
+	case int:
+		return int64(number)
+	}
+	return 0
+}
@@ -0,0 +1,115 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nmstate "github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+var _ = Describe("InterfacesLinkHealth", func() {
+	It("should report oper state, MTU, speed, bond ports and LLDP neighbors", func() {
+		linksHealth, err := InterfacesLinkHealth(nmstate.NewState(`
+interfaces:
+- name: eth1
+  type: ethernet
+  state: up
+  mtu: 9000
+  ethernet:
+    speed: 10000
+  lldp:
+    enabled: true
+    neighbors:
+    - - type: 5
+        system-name: switch1
+- name: eth2
+  type: ethernet
+  state: down
+  mtu: 1500
+- name: bond0
+  type: bond
+  state: up
+  link-aggregation:
+    mode: active-backup
+    port:
+    - eth1
+    - eth2
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(linksHealth).To(Equal([]LinkHealth{
+			{Name: "bond0", Type: "bond", Up: true, IsBond: true, BondActivePorts: 1, BondInactivePorts: 1},
+			{Name: "eth1", Type: "ethernet", Up: true, MTU: 9000, SpeedMbps: 10000, LLDPEnabled: true, LLDPNeighbors: 1},
+			{Name: "eth2", Type: "ethernet", MTU: 1500},
+		}))
+	})
+	It("should support the bond slaves of older nmstate versions", func() {
+		linksHealth, err := InterfacesLinkHealth(nmstate.NewState(`
+interfaces:
+- name: bond0
+  type: bond
+  state: up
+  link-aggregation:
+    slaves:
+    - eth1
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(linksHealth).To(ConsistOf(LinkHealth{Name: "bond0", Type: "bond", Up: true, IsBond: true, BondInactivePorts: 1}))
+	})
+})
+
+var _ = Describe("SelectLinksHealth", func() {
+	linksHealth := []LinkHealth{{Name: "bond0"}, {Name: "eth1"}, {Name: "eth2"}, {Name: "eth3"}}
+	It("should keep only the allowlisted interfaces", func() {
+		selected, dropped := SelectLinksHealth(linksHealth, &nmstate.InterfaceMetrics{IncludeNames: []string{"bond*"}})
+		Expect(selected).To(Equal([]LinkHealth{{Name: "bond0"}}))
+		Expect(dropped).To(BeZero())
+	})
+	It("should drop the interfaces beyond the limit", func() {
+		selected, dropped := SelectLinksHealth(linksHealth, &nmstate.InterfaceMetrics{IncludeNames: []string{"eth*"}, MaxInterfacesPerNode: 2})
+		Expect(selected).To(Equal([]LinkHealth{{Name: "eth1"}, {Name: "eth2"}}))
+		Expect(dropped).To(Equal(1))
+	})
+})
+
+var _ = Describe("JoinInterfaces", func() {
+	It("should replace the summarized interfaces with the full ones", func() {
+		interfaces, summarizedState, err := SplitInterfaces(nmstate.NewState(`interfaces:
+- name: eth1
+  type: ethernet
+  state: up
+  mtu: 1500
+routes:
+  config: []
+  running: []
+`))
+		Expect(err).ToNot(HaveOccurred())
+		joinedState, err := JoinInterfaces(summarizedState, []nmstate.State{interfaces[0].State})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(joinedState).To(MatchYAML(`interfaces:
+- name: eth1
+  type: ethernet
+  state: up
+  mtu: 1500
+routes:
+  config: []
+  running: []
+`))
+	})
+})
@@ -0,0 +1,245 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+	yaml "sigs.k8s.io/yaml"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+const (
+	// RedactedValue replaces every secret found at one of the redaction paths
+	RedactedValue = "<redacted>"
+
+	// RedactPathsEnvVar is a comma separated list of gjson paths that are
+	// redacted on top of DefaultRedactPaths. A "#" path component matches
+	// every element of an array, dots at keys have to be escaped.
+	RedactPathsEnvVar = "STATE_REDACT_PATHS"
+)
+
+// DefaultRedactPaths are the well known nmstate secrets
+var DefaultRedactPaths = []string{
+	`interfaces.#.802\.1x.password`,
+	`interfaces.#.802\.1x.private-key-password`,
+	`interfaces.#.libreswan.psk`,
+	`interfaces.#.macsec.mka-cak`,
+	`interfaces.#.wireguard.private-key`,
+	`interfaces.#.wireguard.peers.#.preshared-key`,
+}
+
+var (
+	redactPathsLock sync.RWMutex
+	redactPaths     = DefaultRedactPaths
+)
+
+// SetRedactPaths configures the paths redacted on top of DefaultRedactPaths.
+func SetRedactPaths(extraPaths []string) {
+	paths := append([]string{}, DefaultRedactPaths...)
+	for _, path := range extraPaths {
+		path = strings.TrimSpace(path)
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	redactPathsLock.Lock()
+	defer redactPathsLock.Unlock()
+	redactPaths = paths
+}
+
+func currentRedactPaths() []string {
+	redactPathsLock.RLock()
+	defer redactPathsLock.RUnlock()
+	return redactPaths
+}
+
+// Redact replaces the values found at the redaction paths with RedactedValue,
+// the state is returned untouched if there is nothing to redact.
+func Redact(state shared.State) (shared.State, error) {
+	if len(state.Raw) == 0 {
+		return state, nil
+	}
+	stateJSON, err := yaml.YAMLToJSON(state.Raw)
+	if err != nil {
+		return state, errors.Wrap(err, "failed converting state to JSON for redaction")
+	}
+
+	redactedJSON, redacted, err := redactJSON(string(stateJSON), currentRedactPaths())
+	if err != nil {
+		return state, err
+	}
+	if !redacted {
+		return state, nil
+	}
+
+	redactedYAML, err := yaml.JSONToYAML([]byte(redactedJSON))
+	if err != nil {
+		return state, errors.Wrap(err, "failed converting redacted state to YAML")
+	}
+	return shared.NewState(string(redactedYAML)), nil
+}
+
+// RedactString redacts a YAML or JSON state so it can be logged or stored
+// at error messages, if the state cannot be parsed it is redacted as a log.
+func RedactString(rawState string) string {
+	if strings.TrimSpace(rawState) == "" {
+		return rawState
+	}
+	if gjson.Valid(rawState) {
+		redactedJSON, _, err := redactJSON(rawState, currentRedactPaths())
+		if err != nil {
+			return RedactLog(rawState)
+		}
+		return redactedJSON
+	}
+	redactedState, err := Redact(shared.NewState(rawState))
+	if err != nil {
+		return RedactLog(rawState)
+	}
+	return redactedState.String()
+}
+
+func redactJSON(stateJSON string, paths []string) (string, bool, error) {
+	redacted := false
+	for _, path := range paths {
+		for _, concretePath := range expandPath(stateJSON, "", splitPath(path)) {
+			if !gjson.Get(stateJSON, concretePath).Exists() {
+				continue
+			}
+			var err error
+			stateJSON, err = sjson.Set(stateJSON, concretePath, RedactedValue)
+			if err != nil {
+				return "", false, errors.Wrapf(err, "failed redacting path '%s'", concretePath)
+			}
+			redacted = true
+		}
+	}
+	return stateJSON, redacted, nil
+}
+
+// expandPath resolves the "#" wildcards so the resulting paths can be
+// used to set values with sjson.
+func expandPath(stateJSON, prefix string, components []string) []string {
+	if len(components) == 0 {
+		return []string{prefix}
+	}
+	component, rest := components[0], components[1:]
+	if component != "#" {
+		return expandPath(stateJSON, joinPath(prefix, component), rest)
+	}
+
+	array := gjson.Get(stateJSON, prefix)
+	if !array.IsArray() {
+		return nil
+	}
+	paths := []string{}
+	for i := range array.Array() {
+		paths = append(paths, expandPath(stateJSON, joinPath(prefix, strconv.Itoa(i)), rest)...)
+	}
+	return paths
+}
+
+// splitPath splits a gjson path by the non escaped dots keeping the
+// escaping so components can be joined back.
+func splitPath(path string) []string {
+	components := []string{}
+	current := strings.Builder{}
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '\\':
+			current.WriteByte(path[i])
+			if i+1 < len(path) {
+				i++
+				current.WriteByte(path[i])
+			}
+		case '.':
+			components = append(components, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(path[i])
+		}
+	}
+	return append(components, current.String())
+}
+
+func joinPath(prefix, component string) string {
+	if prefix == "" {
+		return component
+	}
+	return prefix + "." + component
+}
+
+// RedactLog redacts the values of the redaction paths keys at free form logs,
+// like the nmstatectl verbose ones, where the state cannot be parsed. It
+// matches the YAML, JSON and nmstate debug formats, for example `psk: x`,
+// `"psk": "x"` or `psk: Some("x")`, with dashes or underscores at the key.
+func RedactLog(log string) string {
+	for _, keyRegexp := range redactKeyRegexps() {
+		log = keyRegexp.ReplaceAllString(log, "${1}"+RedactedValue)
+	}
+	return log
+}
+
+func redactKeyRegexps() []*regexp.Regexp {
+	keys := map[string]bool{}
+	regexps := []*regexp.Regexp{}
+	for _, path := range currentRedactPaths() {
+		components := splitPath(path)
+		key := strings.ReplaceAll(components[len(components)-1], `\`, "")
+		if key == "" || key == "#" || keys[key] {
+			continue
+		}
+		keys[key] = true
+		keyPattern := strings.ReplaceAll(regexp.QuoteMeta(strings.ReplaceAll(key, "_", "-")), "-", "[-_]")
+		regexps = append(regexps, regexp.MustCompile(
+			`(?i)("?\b`+keyPattern+`"?\s*[:=]\s*)(Some\("(?:[^"\\]|\\.)*"\)|"(?:[^"\\]|\\.)*"|[^\s,}\]\)]+)`,
+		))
+	}
+	return regexps
+}
+
+// RedactError redacts the states and logs that nmstatectl adds to its error
+// messages, the returned error wraps err so errors.Is and errors.As keep
+// working.
+func RedactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return redactedError{message: RedactLog(err.Error()), err: err}
+}
+
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e redactedError) Error() string {
+	return e.message
+}
+
+func (e redactedError) Unwrap() error {
+	return e.err
+}
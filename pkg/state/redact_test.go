@@ -0,0 +1,194 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nmstate "github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+var _ = Describe("Redact", func() {
+	AfterEach(func() {
+		SetRedactPaths(nil)
+	})
+
+	Context("when the state has secrets at the default paths", func() {
+		It("should replace them with the redacted value", func() {
+			state := nmstate.NewState(`
+interfaces:
+- name: eth1
+  type: ethernet
+  802.1x:
+    identity: client.example.org
+    password: secret1
+    private-key-password: secret2
+- name: hosta_conn
+  type: ipsec
+  libreswan:
+    psk: secret3
+    right: 192.0.2.252
+- name: wg0
+  type: wireguard
+  wireguard:
+    private-key: secret4
+    peers:
+    - public-key: pub1
+      preshared-key: secret5
+    - public-key: pub2
+`)
+			redactedState, err := Redact(state)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(redactedState).To(MatchYAML(nmstate.NewState(`
+interfaces:
+- name: eth1
+  type: ethernet
+  802.1x:
+    identity: client.example.org
+    password: <redacted>
+    private-key-password: <redacted>
+- name: hosta_conn
+  type: ipsec
+  libreswan:
+    psk: <redacted>
+    right: 192.0.2.252
+- name: wg0
+  type: wireguard
+  wireguard:
+    private-key: <redacted>
+    peers:
+    - public-key: pub1
+      preshared-key: <redacted>
+    - public-key: pub2
+`)))
+		})
+	})
+
+	Context("when the state has no secrets", func() {
+		It("should return the state untouched", func() {
+			state := nmstate.NewState(`interfaces:
+- name: eth1
+  type: ethernet
+  state: up
+`)
+			redactedState, err := Redact(state)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(redactedState.String()).To(Equal(state.String()))
+		})
+	})
+
+	Context("when extra redaction paths are configured", func() {
+		BeforeEach(func() {
+			SetRedactPaths([]string{" interfaces.#.description ", ""})
+		})
+		It("should redact them on top of the defaults", func() {
+			state := nmstate.NewState(`
+interfaces:
+- name: eth1
+  type: ethernet
+  description: secret0
+  802.1x:
+    password: secret1
+`)
+			redactedState, err := Redact(state)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(redactedState).To(MatchYAML(nmstate.NewState(`
+interfaces:
+- name: eth1
+  type: ethernet
+  description: <redacted>
+  802.1x:
+    password: <redacted>
+`)))
+		})
+	})
+
+	Context("when FilterOut is called with secrets", func() {
+		It("should redact them", func() {
+			state := nmstate.NewState(`
+interfaces:
+- name: eth1
+  type: ethernet
+  state: up
+  802.1x:
+    password: secret1
+`)
+			filteredState, err := FilterOut(state)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filteredState).To(MatchYAML(nmstate.NewState(`
+interfaces:
+- name: eth1
+  type: ethernet
+  state: up
+  802.1x:
+    password: <redacted>
+`)))
+		})
+	})
+})
+
+var _ = Describe("RedactString", func() {
+	It("should redact JSON states keeping them as JSON", func() {
+		Expect(RedactString(`{"interfaces":[{"name":"wg0","wireguard":{"private-key":"secret"}}]}`)).To(
+			Equal(`{"interfaces":[{"name":"wg0","wireguard":{"private-key":"<redacted>"}}]}`))
+	})
+	It("should redact YAML states", func() {
+		Expect(RedactString("interfaces:\n- name: wg0\n  wireguard:\n    private-key: secret\n")).To(
+			MatchYAML("interfaces:\n- name: wg0\n  wireguard:\n    private-key: <redacted>\n"))
+	})
+	It("should redact states that cannot be parsed as logs", func() {
+		Expect(RedactString("interfaces: [private-key: secret")).To(Equal("interfaces: [private-key: <redacted>"))
+	})
+	It("should keep empty states", func() {
+		Expect(RedactString("")).To(BeEmpty())
+	})
+})
+
+var _ = Describe("RedactLog", func() {
+	It("should redact the secrets printed at nmstate debug logs", func() {
+		Expect(RedactLog(`DEBUG WireguardConfig { private_key: Some("secret"), listen_port: Some(51820) }`)).To(
+			Equal(`DEBUG WireguardConfig { private_key: <redacted>, listen_port: Some(51820) }`))
+	})
+	It("should redact the secrets printed as JSON or YAML", func() {
+		Expect(RedactLog(`{"libreswan":{"psk":"secret","ikev2":"insist"}}`)).To(
+			Equal(`{"libreswan":{"psk":<redacted>,"ikev2":"insist"}}`))
+		Expect(RedactLog("    private-key-password: secret\n    identity: user\n")).To(
+			Equal("    private-key-password: <redacted>\n    identity: user\n"))
+	})
+	It("should keep logs without secrets untouched", func() {
+		log := `INFO Created checkpoint /org/freedesktop/NetworkManager/Checkpoint/1`
+		Expect(RedactLog(log)).To(Equal(log))
+	})
+})
+
+var _ = Describe("RedactError", func() {
+	It("should redact the message and keep wrapping the error", func() {
+		cause := stderrors.New("exit status 1")
+		err := RedactError(fmt.Errorf("interfaces:\n- name: wg0\n  wireguard:\n    private-key: secret\n, : %w", cause))
+		Expect(err.Error()).ToNot(ContainSubstring("secret"))
+		Expect(err.Error()).To(ContainSubstring("private-key: " + RedactedValue))
+		Expect(stderrors.Is(err, cause)).To(BeTrue())
+	})
+	It("should keep nil errors", func() {
+		Expect(RedactError(nil)).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,56 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+
+	yaml "sigs.k8s.io/yaml"
+)
+
+// ChangedSections returns the sorted top level sections (interfaces, routes,
+// dns-resolver...) that differ between both states, including the ones that
+// were added or removed.
+func ChangedSections(previousState, currentState shared.State) ([]string, error) {
+	previousSections := map[string]any{}
+	if err := yaml.Unmarshal(previousState.Raw, &previousSections); err != nil {
+		return nil, err
+	}
+	currentSections := map[string]any{}
+	if err := yaml.Unmarshal(currentState.Raw, &currentSections); err != nil {
+		return nil, err
+	}
+
+	changedSections := []string{}
+	for section, currentValue := range currentSections {
+		previousValue, found := previousSections[section]
+		if !found || !reflect.DeepEqual(previousValue, currentValue) {
+			changedSections = append(changedSections, section)
+		}
+	}
+	for section := range previousSections {
+		if _, found := currentSections[section]; !found {
+			changedSections = append(changedSections, section)
+		}
+	}
+	sort.Strings(changedSections)
+	return changedSections, nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nmstate "github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+var _ = Describe("ChangedSections", func() {
+	It("should return the modified, added and removed sections sorted", func() {
+		changedSections, err := ChangedSections(nmstate.NewState(`
+dns-resolver:
+  running: {}
+interfaces:
+- name: eth1
+  state: up
+routes:
+  running: []
+`), nmstate.NewState(`
+dns-resolver:
+  running: {}
+interfaces:
+- name: eth1
+  state: down
+ovn: {}
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changedSections).To(Equal([]string{"interfaces", "ovn", "routes"}))
+	})
+	It("should return every section when there is no previous state", func() {
+		changedSections, err := ChangedSections(nmstate.State{}, nmstate.NewState("interfaces: []\nroutes: {}\n"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changedSections).To(Equal([]string{"interfaces", "routes"}))
+	})
+	It("should fail with an invalid state", func() {
+		_, err := ChangedSections(nmstate.State{}, nmstate.NewState("interfaces: ["))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,71 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+
+	yaml "sigs.k8s.io/yaml"
+)
+
+// InterfaceState is the state of one of the interfaces
+type InterfaceState struct {
+	Name  string
+	Type  string
+	State shared.State
+}
+
+// SplitInterfaces returns the state of every interface and the state with the
+// interfaces reduced to their name, type and state, so it stays small but
+// still usable by the interfaces counters.
+func SplitInterfaces(currentState shared.State) ([]InterfaceState, shared.State, error) {
+	var state rootState
+	if err := yaml.Unmarshal(currentState.Raw, &state); err != nil {
+		return nil, currentState, err
+	}
+
+	interfaces := []InterfaceState{}
+	summarizedInterfaces := []interfaceState{}
+	for _, iface := range state.Interfaces {
+		ifaceState, err := yaml.Marshal(iface)
+		if err != nil {
+			return nil, currentState, err
+		}
+		interfaces = append(interfaces, InterfaceState{
+			Name:  iface.Name,
+			Type:  iface.Type,
+			State: shared.NewState(string(ifaceState)),
+		})
+
+		summary := map[string]any{"type": iface.Type}
+		if operState, hasState := iface.Data["state"]; hasState {
+			summary["state"] = operState
+		}
+		summarizedInterfaces = append(summarizedInterfaces, interfaceState{
+			interfaceFields: iface.interfaceFields,
+			Data:            summary,
+		})
+	}
+	state.Interfaces = summarizedInterfaces
+
+	summarizedState, err := yaml.Marshal(state)
+	if err != nil {
+		return nil, currentState, err
+	}
+	return interfaces, shared.NewState(string(summarizedState)), nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nmstate "github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+var _ = Describe("SplitInterfaces", func() {
+	It("should return every interface state and summarize them at the remaining state", func() {
+		interfaces, summarizedState, err := SplitInterfaces(nmstate.NewState(`interfaces:
+- name: eth1
+  type: ethernet
+  state: up
+  mtu: 1500
+- name: br-ex
+  type: ovs-bridge
+  state: up
+  bridge:
+    port:
+    - name: eth1
+routes:
+  config: []
+  running:
+  - destination: 0.0.0.0/0
+    next-hop-address: 192.168.66.2
+    next-hop-interface: eth1
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(interfaces).To(HaveLen(2))
+		Expect(interfaces[0].Name).To(Equal("eth1"))
+		Expect(interfaces[0].Type).To(Equal("ethernet"))
+		Expect(interfaces[0].State).To(MatchYAML(`name: eth1
+type: ethernet
+state: up
+mtu: 1500
+`))
+		Expect(interfaces[1].Name).To(Equal("br-ex"))
+		Expect(interfaces[1].Type).To(Equal("ovs-bridge"))
+		Expect(interfaces[1].State).To(MatchYAML(`name: br-ex
+type: ovs-bridge
+state: up
+bridge:
+  port:
+  - name: eth1
+`))
+		Expect(summarizedState).To(MatchYAML(`interfaces:
+- name: eth1
+  type: ethernet
+  state: up
+- name: br-ex
+  type: ovs-bridge
+  state: up
+routes:
+  config: []
+  running:
+  - destination: 0.0.0.0/0
+    next-hop-address: 192.168.66.2
+    next-hop-interface: eth1
+`))
+	})
+})
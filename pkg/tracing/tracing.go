@@ -0,0 +1,105 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+const (
+	// Tracing is the environment variable with the JSON encoded tracing
+	// configuration, the tracing is disabled if not set
+	Tracing = "TRACING"
+
+	tracerName = "github.com/nmstate/kubernetes-nmstate"
+)
+
+// ShutdownFunc flushes the pending spans and stops the exporter
+type ShutdownFunc func(context.Context) error
+
+// ParseTracing decodes the JSON tracing configuration passed by the operator
+// to the handler.
+func ParseTracing(rawTracing string) (*shared.Tracing, error) {
+	tracing := &shared.Tracing{}
+	if err := json.Unmarshal([]byte(rawTracing), tracing); err != nil {
+		return nil, errors.Wrap(err, "failed decoding tracing")
+	}
+	return tracing, nil
+}
+
+// Setup registers a global tracer provider exporting the spans to the
+// configured OTLP collector. If the tracing is not configured the global
+// no-op tracer provider is kept so the spans cost close to nothing.
+func Setup(ctx context.Context, tracing *shared.Tracing, serviceName, nodeName string) (ShutdownFunc, error) {
+	if tracing == nil || tracing.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(tracing.Endpoint)}
+	if tracing.Insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating OTLP trace exporter")
+	}
+
+	samplingPercentage := int32(100)
+	if tracing.SamplingPercentage != nil {
+		samplingPercentage = *tracing.SamplingPercentage
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+			attribute.String("k8s.node.name", nodeName),
+		)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(float64(samplingPercentage)/100))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return tracerProvider.Shutdown, nil
+}
+
+// Start starts a span, it is a no-op if the tracing is not configured.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End records the error, if any, at the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
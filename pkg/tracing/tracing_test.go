@@ -0,0 +1,64 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"go.opentelemetry.io/otel"
+	"k8s.io/utils/ptr"
+
+	"github.com/nmstate/kubernetes-nmstate/api/shared"
+)
+
+var _ = Describe("ParseTracing", func() {
+	It("should decode the tracing configuration", func() {
+		tracing, err := ParseTracing(`{"endpoint":"otel-collector.observability.svc:4317","insecure":true,"samplingPercentage":10}`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tracing).To(Equal(&shared.Tracing{
+			Endpoint:           "otel-collector.observability.svc:4317",
+			Insecure:           true,
+			SamplingPercentage: ptr.To(int32(10)),
+		}))
+	})
+	It("should fail with a malformed configuration", func() {
+		_, err := ParseTracing(`endpoint: otel-collector`)
+		Expect(err).To(MatchError(ContainSubstring("failed decoding tracing")))
+	})
+})
+
+var _ = Describe("Setup", func() {
+	It("should keep the no-op tracer provider when the tracing is not configured", func() {
+		tracerProvider := otel.GetTracerProvider()
+		for _, tracing := range []*shared.Tracing{nil, {Insecure: true}} {
+			shutdown, err := Setup(context.Background(), tracing, "nmstate-handler", "node01")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otel.GetTracerProvider()).To(BeIdenticalTo(tracerProvider))
+			Expect(shutdown(context.Background())).To(Succeed())
+		}
+
+		_, span := Start(context.Background(), "ApplyDesiredState")
+		Expect(span.IsRecording()).To(BeFalse())
+		Expect(span.SpanContext().IsValid()).To(BeFalse())
+		End(span, fmt.Errorf("failed applying desired state"))
+	})
+})
@@ -0,0 +1,46 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package truncate
+
+import "unicode/utf8"
+
+// Head keeps the beginning of s followed by the suffix when s is longer than
+// maxLength bytes. It cuts on a rune boundary so the result stays valid UTF-8.
+func Head(s string, maxLength int, suffix string) string {
+	if len(s) <= maxLength {
+		return s
+	}
+	cut := max(maxLength-len(suffix), 0)
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + suffix
+}
+
+// Tail keeps the end of s preceded by the prefix when s is longer than
+// maxLength bytes. It cuts on a rune boundary so the result stays valid UTF-8.
+func Tail(s string, maxLength int, prefix string) string {
+	if len(s) <= maxLength {
+		return s
+	}
+	start := min(len(s)-maxLength+len(prefix), len(s))
+	for start < len(s) && !utf8.RuneStart(s[start]) {
+		start++
+	}
+	return prefix + s[start:]
+}
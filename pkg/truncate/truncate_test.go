@@ -0,0 +1,52 @@
+/*
+Copyright The Kubernetes NMState Authors.
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package truncate
+
+import (
+	"unicode/utf8"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Truncate", func() {
+	DescribeTable("Head",
+		func(s string, maxLength int, expected string) {
+			truncated := Head(s, maxLength, "...")
+			Expect(truncated).To(Equal(expected))
+			Expect(len(truncated)).To(BeNumerically("<=", max(maxLength, len("..."))))
+			Expect(utf8.ValidString(truncated)).To(BeTrue())
+		},
+		Entry("when it fits", "abcdef", 6, "abcdef"),
+		Entry("when it is longer", "abcdefgh", 6, "abc..."),
+		Entry("when the cut splits a rune", "ab€def", 6, "ab..."),
+		Entry("when only the suffix fits", "abcdef", 3, "..."),
+	)
+	DescribeTable("Tail",
+		func(s string, maxLength int, expected string) {
+			truncated := Tail(s, maxLength, "...")
+			Expect(truncated).To(Equal(expected))
+			Expect(len(truncated)).To(BeNumerically("<=", max(maxLength, len("..."))))
+			Expect(utf8.ValidString(truncated)).To(BeTrue())
+		},
+		Entry("when it fits", "abcdef", 6, "abcdef"),
+		Entry("when it is longer", "abcdefgh", 6, "...fgh"),
+		Entry("when the cut splits a rune", "abcd€f", 6, "...f"),
+		Entry("when only the prefix fits", "abcdef", 3, "..."),
+	)
+})